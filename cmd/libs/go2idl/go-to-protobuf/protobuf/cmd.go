@@ -22,6 +22,7 @@ import (
 	"bytes"
 	"fmt"
 	"log"
+	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
@@ -47,6 +48,8 @@ type Generator struct {
 	KeepGogoproto        bool
 	SkipGeneratedRewrite bool
 	DropEmbeddedFields   string
+	FieldLockFile        string
+	IncludeHeaderCommand bool
 }
 
 func New() *Generator {
@@ -103,6 +106,8 @@ func (g *Generator) BindFlags(flag *flag.FlagSet) {
 	flag.BoolVar(&g.KeepGogoproto, "keep-gogoproto", g.KeepGogoproto, "If true, the generated IDL will contain gogoprotobuf extensions which are normally removed")
 	flag.BoolVar(&g.SkipGeneratedRewrite, "skip-generated-rewrite", g.SkipGeneratedRewrite, "If true, skip fixing up the generated.pb.go file (debugging only).")
 	flag.StringVar(&g.DropEmbeddedFields, "drop-embedded-fields", g.DropEmbeddedFields, "Comma-delimited list of embedded Go types to omit from generated protobufs")
+	flag.StringVar(&g.FieldLockFile, "field-lock-file", g.FieldLockFile, "An optional JSON lock file pinning protobuf field numbers; conflicting tags are an error and new fields are appended to the file.")
+	flag.BoolVar(&g.IncludeHeaderCommand, "include-header-command", g.IncludeHeaderCommand, "If true, record the full command line in the header of generated files. Off by default because it embeds machine-specific paths and makes output differ between checkouts.")
 }
 
 func Run(g *Generator) {
@@ -133,6 +138,19 @@ func Run(g *Generator) {
 		log.Fatalf("Failed loading boilerplate: %v", err)
 	}
 
+	var fieldLock *FieldLock
+	if len(g.FieldLockFile) > 0 {
+		fieldLock, err = LoadFieldLock(g.FieldLockFile)
+		if err != nil {
+			log.Fatalf("Failed loading field lock file: %v", err)
+		}
+	}
+
+	headerCommand := ""
+	if g.IncludeHeaderCommand {
+		headerCommand = strings.Join(os.Args, " ")
+	}
+
 	protobufNames := NewProtobufNamer()
 	outputPackages := generator.Packages{}
 	for _, d := range strings.Split(g.Packages, ",") {
@@ -151,7 +169,8 @@ func Run(g *Generator) {
 			d = parts[0]
 			name = parts[1]
 		}
-		p := newProtobufPackage(d, name, generateAllTypes, omitTypes)
+		p := newProtobufPackage(d, name, generateAllTypes, omitTypes, "", headerCommand)
+		p.FieldLock = fieldLock
 		header := append([]byte{}, boilerplate...)
 		header = append(header, p.HeaderText...)
 		p.HeaderText = header
@@ -215,6 +234,12 @@ func Run(g *Generator) {
 		log.Fatalf("Failed executing local generator: %v", err)
 	}
 
+	if fieldLock != nil {
+		if err := fieldLock.Save(); err != nil {
+			log.Fatalf("Failed writing field lock file: %v", err)
+		}
+	}
+
 	if g.OnlyIDL {
 		return
 	}
@@ -267,6 +292,9 @@ func Run(g *Generator) {
 		if err := RewriteGeneratedGogoProtobufFile(outputPath, p.ExtractGeneratedType, p.OptionalTypeName, buf.Bytes()); err != nil {
 			log.Fatalf("Unable to rewrite generated %s: %v", outputPath, err)
 		}
+		if errs := p.ExtractErrors(); len(errs) != 0 {
+			log.Fatalf("Unable to rewrite generated %s: %v", outputPath, errs)
+		}
 
 		// sort imports
 		cmd = exec.Command("goimports", "-w", outputPath)
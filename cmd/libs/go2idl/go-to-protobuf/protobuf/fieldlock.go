@@ -0,0 +1,102 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+)
+
+// FieldLock pins protobuf field numbers in a checked-in lock file so that
+// generated IDL stays wire compatible across runs. The generator verifies
+// every field's tag number against the lock and errors on a mismatch; fields
+// not yet present in the lock are appended and written back on Save.
+type FieldLock struct {
+	path string
+
+	mu sync.Mutex
+	// Tags maps message name to field name to the pinned tag number.
+	Tags  map[string]map[string]int
+	dirty bool
+}
+
+// LoadFieldLock reads a field lock file. A missing file yields an empty lock
+// that will be populated as messages are generated.
+func LoadFieldLock(path string) (*FieldLock, error) {
+	lock := &FieldLock{
+		path: path,
+		Tags: make(map[string]map[string]int),
+	}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return lock, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &lock.Tags); err != nil {
+		return nil, fmt.Errorf("unable to parse field lock file %s: %v", path, err)
+	}
+	return lock, nil
+}
+
+// Apply verifies the tag numbers assigned to the fields of the named message
+// against the lock, returning an error when a field's tag conflicts with the
+// pinned number. Fields not present in the lock are recorded.
+func (l *FieldLock) Apply(message string, fields []protoField) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	locked := l.Tags[message]
+	for i := range fields {
+		field := &fields[i]
+		pinned, ok := locked[field.Name]
+		if !ok {
+			if locked == nil {
+				locked = make(map[string]int)
+				l.Tags[message] = locked
+			}
+			locked[field.Name] = field.Tag
+			l.dirty = true
+			continue
+		}
+		if pinned != field.Tag {
+			return fmt.Errorf("message %s: field %s has tag %d but the lock file pins it to %d", message, field.Name, field.Tag, pinned)
+		}
+	}
+	return nil
+}
+
+// Save writes the lock file back to disk if any new fields were recorded.
+func (l *FieldLock) Save() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.dirty {
+		return nil
+	}
+	data, err := json.MarshalIndent(l.Tags, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(l.path, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	l.dirty = false
+	return nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFieldLock(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fieldlock")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "fields.lock")
+	if err := ioutil.WriteFile(path, []byte(`{"Pod":{"metadata":1,"spec":2}}`), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lock, err := LoadFieldLock(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// matching tags pass, and a new field is recorded
+	fields := []protoField{
+		{Name: "metadata", Tag: 1},
+		{Name: "spec", Tag: 2},
+		{Name: "status", Tag: 3},
+	}
+	if err := lock.Apply("Pod", fields); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lock.Tags["Pod"]["status"] != 3 {
+		t.Errorf("expected new field to be recorded, got %v", lock.Tags["Pod"])
+	}
+
+	// a conflicting tag is an error
+	if err := lock.Apply("Pod", []protoField{{Name: "metadata", Tag: 7}}); err == nil {
+		t.Errorf("expected error for conflicting tag")
+	}
+
+	// the new field is written back
+	if err := lock.Save(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	reloaded, err := LoadFieldLock(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reloaded.Tags["Pod"]["status"] != 3 {
+		t.Errorf("expected saved lock to contain new field, got %v", reloaded.Tags["Pod"])
+	}
+}
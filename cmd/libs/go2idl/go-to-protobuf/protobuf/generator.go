@@ -42,12 +42,53 @@ type genProtoIDL struct {
 	generateAll    bool
 	omitGogo       bool
 	omitFieldTypes map[types.Name]struct{}
+	services       []ServiceSpec
+	jsonNames      map[string]map[string]string
+	fieldLock      *FieldLock
+	goPackage      string
+}
+
+// goPackageOption returns the value emitted as the go_package option,
+// defaulting to the name of the Go package being generated.
+func (g *genProtoIDL) goPackageOption() string {
+	if len(g.goPackage) != 0 {
+		return g.goPackage
+	}
+	return g.localGoPackage.Name
+}
+
+// Init renders any proto service definitions for the package before the
+// message bodies are generated. Services come from the package configuration
+// and from +protobuf.service markers on interface types.
+func (g *genProtoIDL) Init(c *generator.Context, w io.Writer) error {
+	services := discoverServices(g.services, c.Universe[g.localGoPackage.Package])
+	if len(services) == 0 {
+		return nil
+	}
+	locator := &protobufLocator{
+		namer:    c.Namers["proto"].(ProtobufFromGoNamer),
+		tracker:  g.imports,
+		universe: c.Universe,
+
+		localGoPackage: g.localGoPackage.Package,
+	}
+	for _, s := range services {
+		t := c.Universe.Type(types.Name{Package: g.localGoPackage.Package, Name: s.Interface})
+		name := s.Name
+		if len(name) == 0 {
+			name = s.Interface
+		}
+		if err := renderService(w, name, t, locator); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (g *genProtoIDL) PackageVars(c *generator.Context) []string {
 	if g.omitGogo {
 		return []string{
-			fmt.Sprintf("option go_package = %q;", g.localGoPackage.Name),
+			fmt.Sprintf("option go_package = %q;", g.goPackageOption()),
 		}
 	}
 	return []string{
@@ -59,7 +100,7 @@ func (g *genProtoIDL) PackageVars(c *generator.Context) []string {
 		"option (gogoproto.goproto_unrecognized_all) = false;",
 		"option (gogoproto.goproto_enum_prefix_all) = false;",
 		"option (gogoproto.goproto_getters_all) = false;",
-		fmt.Sprintf("option go_package = %q;", g.localGoPackage.Name),
+		fmt.Sprintf("option go_package = %q;", g.goPackageOption()),
 	}
 }
 func (g *genProtoIDL) Filename() string { return g.OptionalName + ".proto" }
@@ -169,6 +210,8 @@ func (g *genProtoIDL) GenerateType(c *generator.Context, t *types.Type, w io.Wri
 
 		omitGogo:       g.omitGogo,
 		omitFieldTypes: g.omitFieldTypes,
+		jsonNames:      g.jsonNames,
+		fieldLock:      g.fieldLock,
 
 		t: t,
 	}
@@ -250,10 +293,32 @@ type bodyGen struct {
 	localPackage   types.Name
 	omitGogo       bool
 	omitFieldTypes map[types.Name]struct{}
+	jsonNames      map[string]map[string]string
+	fieldLock      *FieldLock
 
 	t *types.Type
 }
 
+// applyJSONNames attaches a json_name option to any field of the named
+// message that has an explicit JSON name configured. Fields without a
+// configured name are left untouched.
+func applyJSONNames(messageName string, fields []protoField, jsonNames map[string]map[string]string) {
+	names := jsonNames[messageName]
+	if names == nil {
+		return
+	}
+	for i := range fields {
+		jsonName, ok := names[fields[i].Name]
+		if !ok {
+			continue
+		}
+		if fields[i].Extras == nil {
+			fields[i].Extras = make(map[string]string)
+		}
+		fields[i].Extras["json_name"] = strconv.Quote(jsonName)
+	}
+}
+
 func (b bodyGen) unknown(sw *generator.SnippetWriter) error {
 	return fmt.Errorf("not sure how to generate: %#v", b.t)
 }
@@ -301,7 +366,15 @@ func (b bodyGen) doStruct(sw *generator.SnippetWriter) error {
 	var fields []protoField
 	options := []string{}
 	allOptions := types.ExtractCommentTags("+", b.t.CommentLines)
-	for k, v := range allOptions {
+	// iterate the tags in a stable order so repeated runs produce
+	// byte-identical output
+	optionKeys := make([]string, 0, len(allOptions))
+	for k := range allOptions {
+		optionKeys = append(optionKeys, k)
+	}
+	sort.Strings(optionKeys)
+	for _, k := range optionKeys {
+		v := allOptions[k]
 		switch {
 		case strings.HasPrefix(k, "protobuf.options."):
 			key := strings.TrimPrefix(k, "protobuf.options.")
@@ -361,6 +434,14 @@ func (b bodyGen) doStruct(sw *generator.SnippetWriter) error {
 		fields = memberFields
 	}
 
+	applyJSONNames(b.t.Name.Name, fields, b.jsonNames)
+
+	if b.fieldLock != nil {
+		if err := b.fieldLock.Apply(b.t.Name.Name, fields); err != nil {
+			return err
+		}
+	}
+
 	out := sw.Out()
 	genComment(out, b.t.CommentLines, "")
 	sw.Do(`message $.Name.Name$ {
@@ -375,6 +456,12 @@ func (b bodyGen) doStruct(sw *generator.SnippetWriter) error {
 	}
 
 	for i, field := range fields {
+		if field.MapEntry != nil {
+			fmt.Fprintf(out, "  message %s {\n", field.Type.Name.Name)
+			sw.Do("    optional $.Key|local$ key = 1;\n", field.MapEntry)
+			sw.Do("    optional $.Value|local$ value = 2;\n", field.MapEntry)
+			fmt.Fprintf(out, "  }\n\n")
+		}
 		genComment(out, field.CommentLines, "  ")
 		fmt.Fprintf(out, "  ")
 		switch {
@@ -424,9 +511,37 @@ type protoField struct {
 	Nullable bool
 	Extras   map[string]string
 
+	// MapEntry holds the key and value types of a Go map whose key protobuf
+	// cannot accept in map<>; the field is rendered as a repeated nested
+	// entry message instead.
+	MapEntry *protoMapEntry
+
 	CommentLines []string
 }
 
+// protoMapEntry is the key/value pair of a map field rendered as a nested
+// entry message.
+type protoMapEntry struct {
+	Key   *types.Type
+	Value *types.Type
+}
+
+// isValidProtobufMapKey returns true if the resolved protobuf type may be
+// used as a map<> key. Protobuf restricts map keys to integral, boolean and
+// string types; floating point, bytes and message types are rejected.
+func isValidProtobufMapKey(t *types.Type) bool {
+	if t == nil || len(t.Name.Package) != 0 {
+		return false
+	}
+	switch t.Name.Name {
+	case "string", "bool",
+		"int32", "int64", "uint32", "uint64",
+		"sint32", "sint64", "fixed32", "fixed64", "sfixed32", "sfixed64":
+		return true
+	}
+	return false
+}
+
 var (
 	errUnrecognizedType = fmt.Errorf("did not recognize the provided type")
 )
@@ -665,6 +780,30 @@ func membersToFields(locator ProtobufLocator, t *types.Type, localPackage types.
 			field.Nullable = true
 		}
 
+		if field.Map && !isValidProtobufMapKey(field.Type.Key) {
+			// The key type cannot appear in a protobuf map<>; fall back to a
+			// repeated nested key/value entry message named after the field.
+			field.MapEntry = &protoMapEntry{Key: field.Type.Key, Value: field.Type.Elem}
+			field.Type = &types.Type{
+				Kind: types.Protobuf,
+				Name: types.Name{Name: m.Name + "Entry", Package: localPackage.Package, Path: localPackage.Path},
+			}
+			field.Map = false
+			field.Repeated = true
+			field.Nullable = true
+			delete(field.Extras, "(gogoproto.casttype)")
+			delete(field.Extras, "(gogoproto.castkey)")
+			delete(field.Extras, "(gogoproto.castvalue)")
+		}
+
+		// A "+protobuf.nullable=false" marker forces a value (non-pointer)
+		// field in the generated Go struct for a member that would otherwise
+		// be nullable, such as a pointer. Under omitGogo the extra is dropped
+		// with the rest of the gogo extensions when the field is rendered.
+		if vals, ok := types.ExtractCommentTags("+", m.CommentLines)["protobuf.nullable"]; ok && len(vals) > 0 && vals[0] == "false" {
+			field.Nullable = false
+		}
+
 		if !field.Nullable {
 			field.Extras["(gogoproto.nullable)"] = "false"
 		}
@@ -708,7 +847,20 @@ func membersToFields(locator ProtobufLocator, t *types.Type, localPackage types.
 	return fields, nil
 }
 
+// genComment writes the Go doc comment lines as leading // comments in the
+// generated IDL. Directives to this generator ("+protobuf...") are consumed
+// rather than emitted, trailing blank lines are dropped, and embedded line
+// breaks of any flavor (\n, \r\n, \r) start a new // line so comment text can
+// never escape the line comment it is rendered into.
 func genComment(out io.Writer, lines []string, indent string) {
+	filtered := make([]string, 0, len(lines))
+	for _, c := range lines {
+		if strings.HasPrefix(strings.TrimSpace(c), "+protobuf") {
+			continue
+		}
+		filtered = append(filtered, c)
+	}
+	lines = filtered
 	for {
 		l := len(lines)
 		if l == 0 || len(lines[l-1]) != 0 {
@@ -717,7 +869,11 @@ func genComment(out io.Writer, lines []string, indent string) {
 		lines = lines[:l-1]
 	}
 	for _, c := range lines {
-		fmt.Fprintf(out, "%s// %s\n", indent, c)
+		c = strings.Replace(c, "\r\n", "\n", -1)
+		c = strings.Replace(c, "\r", "\n", -1)
+		for _, line := range strings.Split(c, "\n") {
+			fmt.Fprintf(out, "%s// %s\n", indent, line)
+		}
 	}
 }
 
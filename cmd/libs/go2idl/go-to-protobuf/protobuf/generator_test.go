@@ -0,0 +1,311 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"k8s.io/gengo/generator"
+	"k8s.io/gengo/namer"
+	"k8s.io/gengo/types"
+)
+
+func TestApplyJSONNames(t *testing.T) {
+	fields := []protoField{
+		{Name: "metadata"},
+		{Name: "spec"},
+	}
+	jsonNames := map[string]map[string]string{
+		"Pod": {"metadata": "meta"},
+	}
+
+	applyJSONNames("Pod", fields, jsonNames)
+
+	if got := fields[0].Extras["json_name"]; got != `"meta"` {
+		t.Errorf("expected json_name option for configured field, got %q", got)
+	}
+	if _, ok := fields[1].Extras["json_name"]; ok {
+		t.Errorf("unexpected json_name option on unconfigured field")
+	}
+
+	// messages without configuration are untouched
+	other := []protoField{{Name: "metadata"}}
+	applyJSONNames("Service", other, jsonNames)
+	if _, ok := other[0].Extras["json_name"]; ok {
+		t.Errorf("unexpected json_name option on unconfigured message")
+	}
+}
+
+// TestDoStructDeterministicOutput generates the same message twice and
+// asserts the outputs are byte-identical, guarding against map iteration
+// order leaking into the emitted IDL.
+func TestDoStructDeterministicOutput(t *testing.T) {
+	localPackage := types.Name{Package: "k8s.io.kubernetes.pkg.api.v1", Path: "k8s.io/kubernetes/pkg/api/v1"}
+	msg := &types.Type{
+		Name: types.Name{Package: "k8s.io/kubernetes/pkg/api/v1", Name: "Frobber"},
+		Kind: types.Struct,
+		CommentLines: []string{
+			"+protobuf.options.(gogoproto.goproto_stringer)=false",
+			"+protobuf.options.marshal=false",
+			"+protobuf.options.deprecated=true",
+		},
+	}
+
+	render := func() string {
+		buf := &bytes.Buffer{}
+		c := &generator.Context{Namers: namer.NameSystems{"local": localNamer{localPackage}}}
+		sw := generator.NewSnippetWriter(buf, c, "$", "$")
+		b := bodyGen{
+			locator:      fakeServiceLocator{},
+			localPackage: localPackage,
+			t:            msg,
+		}
+		if err := b.doStruct(sw); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := sw.Error(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return buf.String()
+	}
+
+	first := render()
+	for i := 0; i < 10; i++ {
+		if next := render(); next != first {
+			t.Fatalf("output differed between runs:\n%s\n---\n%s", first, next)
+		}
+	}
+	if !strings.Contains(first, "option deprecated = true;") {
+		t.Errorf("missing struct option in output: %s", first)
+	}
+}
+
+// mapFieldLocator resolves fundamental types in addition to messages, so map
+// keys and values can be builtins.
+type mapFieldLocator struct {
+	fakeServiceLocator
+}
+
+func (mapFieldLocator) ProtoTypeFor(t *types.Type) (*types.Type, error) {
+	if fundamental, ok := isFundamentalProtoType(t); ok {
+		return fundamental, nil
+	}
+	return &types.Type{Name: t.Name, Kind: types.Protobuf}, nil
+}
+
+func TestMapFields(t *testing.T) {
+	localPackage := types.Name{Package: "k8s.io.kubernetes.pkg.api.v1", Path: "k8s.io/kubernetes/pkg/api/v1"}
+	stringType := &types.Type{Name: types.Name{Name: "string"}, Kind: types.Builtin}
+	floatType := &types.Type{Name: types.Name{Name: "float64"}, Kind: types.Builtin}
+	msg := &types.Type{
+		Name: types.Name{Package: "k8s.io/kubernetes/pkg/api/v1", Name: "Frobber"},
+		Kind: types.Struct,
+		Members: []types.Member{
+			{Name: "Labels", Type: &types.Type{Name: types.Name{Name: "map[string]string"}, Kind: types.Map, Key: stringType, Elem: stringType}},
+			{Name: "Weights", Type: &types.Type{Name: types.Name{Name: "map[float64]string"}, Kind: types.Map, Key: floatType, Elem: stringType}},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	c := &generator.Context{Namers: namer.NameSystems{"local": localNamer{localPackage}}}
+	sw := generator.NewSnippetWriter(buf, c, "$", "$")
+	b := bodyGen{
+		locator:      mapFieldLocator{},
+		localPackage: localPackage,
+		t:            msg,
+	}
+	if err := b.doStruct(sw); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sw.Error(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+
+	// a string key is a valid protobuf map key
+	if !strings.Contains(out, "map<string, string> labels = 1") {
+		t.Errorf("missing map<> field: %s", out)
+	}
+
+	// a float key is not, so the field degrades to repeated entry messages
+	if strings.Contains(out, "map<double") {
+		t.Errorf("float keys must not be emitted as map<>: %s", out)
+	}
+	if !strings.Contains(out, "repeated WeightsEntry weights = 2") {
+		t.Errorf("missing repeated entry fallback field: %s", out)
+	}
+	if !strings.Contains(out, "message WeightsEntry {") ||
+		!strings.Contains(out, "optional double key = 1;") ||
+		!strings.Contains(out, "optional string value = 2;") {
+		t.Errorf("missing nested entry message: %s", out)
+	}
+}
+
+// TestNullableMarker asserts a "+protobuf.nullable=false" field marker makes
+// a pointer member non-nullable — emitting the gogoproto nullable extension
+// on exactly that field — without leaking the marker into the generated
+// comments, and that the extension disappears when gogo extensions are
+// omitted.
+func TestNullableMarker(t *testing.T) {
+	localPackage := types.Name{Package: "k8s.io.kubernetes.pkg.api.v1", Path: "k8s.io/kubernetes/pkg/api/v1"}
+	metaType := &types.Type{Name: types.Name{Name: "ObjectMeta"}, Kind: types.Struct}
+	specType := &types.Type{Name: types.Name{Name: "PodSpec"}, Kind: types.Struct}
+	msg := &types.Type{
+		Name: types.Name{Package: "k8s.io/kubernetes/pkg/api/v1", Name: "Frobber"},
+		Kind: types.Struct,
+		Members: []types.Member{
+			{Name: "Meta", Type: &types.Type{Name: types.Name{Name: "*ObjectMeta"}, Kind: types.Pointer, Elem: metaType}},
+			{
+				Name:         "Spec",
+				Type:         &types.Type{Name: types.Name{Name: "*PodSpec"}, Kind: types.Pointer, Elem: specType},
+				CommentLines: []string{"+protobuf.nullable=false"},
+			},
+		},
+	}
+
+	render := func(omitGogo bool) string {
+		buf := &bytes.Buffer{}
+		c := &generator.Context{Namers: namer.NameSystems{"local": localNamer{localPackage}}}
+		sw := generator.NewSnippetWriter(buf, c, "$", "$")
+		b := bodyGen{
+			locator:      mapFieldLocator{},
+			localPackage: localPackage,
+			omitGogo:     omitGogo,
+			t:            msg,
+		}
+		if err := b.doStruct(sw); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := sw.Error(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return buf.String()
+	}
+
+	out := render(false)
+	if !strings.Contains(out, `optional PodSpec spec = 2 [(gogoproto.customname) = "Spec", (gogoproto.nullable) = false];`) {
+		t.Errorf("missing nullable extension on the marked field: %s", out)
+	}
+	if !strings.Contains(out, `optional ObjectMeta meta = 1 [(gogoproto.customname) = "Meta"];`) {
+		t.Errorf("unmarked pointer field must stay nullable: %s", out)
+	}
+	if strings.Contains(out, "+protobuf.nullable") {
+		t.Errorf("marker leaked into the generated comments: %s", out)
+	}
+
+	if out := render(true); strings.Contains(out, "gogoproto") {
+		t.Errorf("gogo extensions must not be emitted with gogo omitted: %s", out)
+	}
+}
+
+// TestFieldComments asserts Go doc comments are carried into the generated
+// IDL as leading // comments, and that comment text cannot break out of the
+// line comment it is rendered into.
+func TestFieldComments(t *testing.T) {
+	localPackage := types.Name{Package: "k8s.io.kubernetes.pkg.api.v1", Path: "k8s.io/kubernetes/pkg/api/v1"}
+	stringType := &types.Type{Name: types.Name{Name: "string"}, Kind: types.Builtin}
+	msg := &types.Type{
+		Name:         types.Name{Package: "k8s.io/kubernetes/pkg/api/v1", Name: "Frobber"},
+		Kind:         types.Struct,
+		CommentLines: []string{"Frobber frobs.", "", "It has fields."},
+		Members: []types.Member{
+			{
+				Name:         "Height",
+				Type:         stringType,
+				CommentLines: []string{"Height is how tall the frobber is.", "Stray\nnewlines stay\rcommented."},
+			},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	c := &generator.Context{Namers: namer.NameSystems{"local": localNamer{localPackage}}}
+	sw := generator.NewSnippetWriter(buf, c, "$", "$")
+	b := bodyGen{
+		locator:      mapFieldLocator{},
+		localPackage: localPackage,
+		t:            msg,
+	}
+	if err := b.doStruct(sw); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := sw.Error(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+
+	if !strings.Contains(out, "// Frobber frobs.\n") {
+		t.Errorf("missing message comment: %s", out)
+	}
+	if !strings.Contains(out, "  // Height is how tall the frobber is.\n  // Stray\n  // newlines stay\n") {
+		t.Errorf("missing or unsanitized field comment: %s", out)
+	}
+	if strings.Contains(out, "\r") {
+		t.Errorf("carriage return leaked into output: %q", out)
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if strings.Contains(line, "newlines stay") && !strings.HasPrefix(strings.TrimSpace(line), "//") {
+			t.Errorf("comment text escaped its line comment: %q", line)
+		}
+	}
+}
+
+func TestGoPackageOption(t *testing.T) {
+	testCases := []struct {
+		name      string
+		goPackage string
+		omitGogo  bool
+		expect    string
+	}{
+		{
+			name:   "default",
+			expect: `option go_package = "v1";`,
+		},
+		{
+			name:      "override",
+			goPackage: "k8s.io/kubernetes/pkg/api/v1",
+			expect:    `option go_package = "k8s.io/kubernetes/pkg/api/v1";`,
+		},
+		{
+			name:     "override without gogo",
+			omitGogo: true,
+			expect:   `option go_package = "v1";`,
+		},
+	}
+	for _, test := range testCases {
+		g := &genProtoIDL{
+			localGoPackage: types.Name{Package: "k8s.io/kubernetes/pkg/api/v1", Name: "v1"},
+			omitGogo:       test.omitGogo,
+			goPackage:      test.goPackage,
+		}
+		vars := g.PackageVars(nil)
+		count := 0
+		for _, v := range vars {
+			if !strings.HasPrefix(v, "option go_package") {
+				continue
+			}
+			count++
+			if v != test.expect {
+				t.Errorf("%s: expected %q, got %q", test.name, test.expect, v)
+			}
+		}
+		if count != 1 {
+			t.Errorf("%s: expected exactly one go_package option, got %d in %v", test.name, count, vars)
+		}
+	}
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package protobuf
 
 import (
+	"path"
+
 	"k8s.io/gengo/namer"
 	"k8s.io/gengo/types"
 )
@@ -37,6 +39,29 @@ func NewImportTracker(local types.Name, typesToAdd ...*types.Type) *ImportTracke
 	}
 }
 
+// Deny marks the provided package paths as opaque: types from those packages
+// are never added to the tracked imports. A type matches if its package, its
+// path, or the directory of its path is in the set.
+func (tracker *ImportTracker) Deny(packages map[string]struct{}) {
+	if len(packages) == 0 {
+		return
+	}
+	invalid := tracker.IsInvalidType
+	tracker.IsInvalidType = func(t *types.Type) bool {
+		if _, ok := packages[t.Name.Package]; ok {
+			return true
+		}
+		if _, ok := packages[t.Name.Path]; ok {
+			return true
+		}
+		// the import path of a generated proto lives inside its Go package
+		if _, ok := packages[path.Dir(t.Name.Path)]; ok {
+			return true
+		}
+		return invalid(t)
+	}
+}
+
 // AddNullable ensures that support for the nullable Gogo-protobuf extension is added.
 func (tracker *ImportTracker) AddNullable() {
 	tracker.AddType(&types.Type{
@@ -163,6 +163,7 @@ func (n *protobufNamer) AssignTypesToPackages(c *generator.Context) error {
 		local := make(typeNameSet)
 		optional := make(map[types.Name]struct{})
 		p.Imports = NewImportTracker(p.ProtoTypeName())
+		p.Imports.Deny(p.DeniedPackages)
 		for _, t := range c.Order {
 			if t.Name.Package != p.PackagePath {
 				continue
@@ -29,24 +29,35 @@ import (
 	"k8s.io/gengo/types"
 )
 
-func newProtobufPackage(packagePath, packageName string, generateAll bool, omitFieldTypes map[types.Name]struct{}) *protobufPackage {
+func newProtobufPackage(packagePath, packageName string, generateAll bool, omitFieldTypes map[types.Name]struct{}, outputBaseName string, headerCommand string) *protobufPackage {
+	header := `
+// This file was autogenerated by go-to-protobuf. Do not edit it manually!
+
+`
+	// Recording the invoking command line embeds machine-specific paths into
+	// every generated file, so it is only included when the caller asks for it.
+	if len(headerCommand) > 0 {
+		header = fmt.Sprintf(`
+// This file was autogenerated by go-to-protobuf. Do not edit it manually!
+//
+// $ %s
+
+`, headerCommand)
+	}
 	pkg := &protobufPackage{
 		DefaultPackage: generator.DefaultPackage{
 			// The protobuf package name (foo.bar.baz)
 			PackageName: packageName,
 			// A path segment relative to the GOPATH root (foo/bar/baz)
 			PackagePath: packagePath,
-			HeaderText: []byte(
-				`
-// This file was autogenerated by go-to-protobuf. Do not edit it manually!
-
-`),
+			HeaderText:  []byte(header),
 			PackageDocumentation: []byte(fmt.Sprintf(
 				`// Package %s is an autogenerated protobuf IDL.
 `, packageName)),
 		},
 		GenerateAll:    generateAll,
 		OmitFieldTypes: omitFieldTypes,
+		OutputBaseName: outputBaseName,
 	}
 	pkg.FilterFunc = pkg.filterFunc
 	pkg.GeneratorFunc = pkg.generatorFunc
@@ -75,6 +86,10 @@ type protobufPackage struct {
 	// A list of field types that will be excluded from the output struct
 	OmitFieldTypes map[types.Name]struct{}
 
+	// A set of Go package paths whose types are treated as opaque: their
+	// types are never generated and their imports are never added
+	DeniedPackages map[string]struct{}
+
 	// A list of names that this package exports
 	LocalNames map[string]struct{}
 
@@ -85,6 +100,30 @@ type protobufPackage struct {
 	// A list of struct tags to generate onto named struct fields
 	StructTags map[string]map[string]string
 
+	// A list of proto services to generate from Go interfaces in this package
+	Services []ServiceSpec
+
+	// A map of message name to field name to the json_name option to emit
+	// for that field
+	JSONNames map[string]map[string]string
+
+	// An optional lock pinning field numbers across generator runs
+	FieldLock *FieldLock
+
+	// The base name of the emitted files, "generated" when empty
+	OutputBaseName string
+
+	// The value of the go_package option emitted into the .proto file,
+	// the Go package name when empty
+	GoPackageOption string
+
+	// If true, a struct field that declares multiple names with a single
+	// protobuf tag aborts generation instead of being skipped
+	StrictTags bool
+
+	// Errors encountered while extracting generated types in strict mode
+	extractErrors []error
+
 	// An import tracker for this package
 	Imports *ImportTracker
 }
@@ -107,6 +146,9 @@ func (p *protobufPackage) ProtoTypeName() types.Name {
 }
 
 func (p *protobufPackage) filterFunc(c *generator.Context, t *types.Type) bool {
+	if _, ok := p.DeniedPackages[t.Name.Package]; ok {
+		return false
+	}
 	switch t.Kind {
 	case types.Func, types.Chan:
 		return false
@@ -159,8 +201,14 @@ func (p *protobufPackage) ExtractGeneratedType(t *ast.TypeSpec) bool {
 				continue
 			}
 			if len(f.Names) > 1 {
-				log.Printf("WARNING: struct %s field %d %s: defined multiple names but single protobuf tag", t.Name.Name, i, f.Names[0].Name)
-				// TODO hard error?
+				// a single tag cannot be associated with more than one name,
+				// so storing it under any of them would mis-tag the rest
+				if p.StrictTags {
+					p.extractErrors = append(p.extractErrors, fmt.Errorf("struct %s field %d %s: defined multiple names but single protobuf tag", t.Name.Name, i, f.Names[0].Name))
+				} else {
+					log.Printf("WARNING: struct %s field %d %s: defined multiple names but single protobuf tag, skipping", t.Name.Name, i, f.Names[0].Name)
+				}
+				continue
 			}
 			if p.StructTags == nil {
 				p.StructTags = make(map[string]map[string]string)
@@ -179,6 +227,12 @@ func (p *protobufPackage) ExtractGeneratedType(t *ast.TypeSpec) bool {
 	return true
 }
 
+// ExtractErrors returns the errors encountered by ExtractGeneratedType in
+// strict mode. Generation must be aborted if any are present.
+func (p *protobufPackage) ExtractErrors() []error {
+	return p.extractErrors
+}
+
 func (p *protobufPackage) generatorFunc(c *generator.Context) []generator.Generator {
 	generators := []generator.Generator{}
 
@@ -186,7 +240,7 @@ func (p *protobufPackage) generatorFunc(c *generator.Context) []generator.Genera
 
 	generators = append(generators, &genProtoIDL{
 		DefaultGen: generator.DefaultGen{
-			OptionalName: "generated",
+			OptionalName: p.OutputName(),
 		},
 		localPackage:   types.Name{Package: p.PackageName, Path: p.PackagePath},
 		localGoPackage: types.Name{Package: p.PackagePath, Name: p.GoPackageName()},
@@ -194,6 +248,10 @@ func (p *protobufPackage) generatorFunc(c *generator.Context) []generator.Genera
 		generateAll:    p.GenerateAll,
 		omitGogo:       p.OmitGogo,
 		omitFieldTypes: p.OmitFieldTypes,
+		services:       p.Services,
+		jsonNames:      p.JSONNames,
+		fieldLock:      p.FieldLock,
+		goPackage:      p.GoPackageOption,
 	})
 	return generators
 }
@@ -202,12 +260,21 @@ func (p *protobufPackage) GoPackageName() string {
 	return filepath.Base(p.PackagePath)
 }
 
+// OutputName returns the base name of the files this package emits,
+// "generated" unless a custom name was configured.
+func (p *protobufPackage) OutputName() string {
+	if len(p.OutputBaseName) == 0 {
+		return "generated"
+	}
+	return p.OutputBaseName
+}
+
 func (p *protobufPackage) ImportPath() string {
-	return filepath.Join(p.PackagePath, "generated.proto")
+	return filepath.Join(p.PackagePath, p.OutputName()+".proto")
 }
 
 func (p *protobufPackage) OutputPath() string {
-	return filepath.Join(p.PackagePath, "generated.pb.go")
+	return filepath.Join(p.PackagePath, p.OutputName()+".pb.go")
 }
 
 var (
@@ -0,0 +1,181 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+	"testing"
+
+	"k8s.io/gengo/types"
+)
+
+func TestPackageOutputName(t *testing.T) {
+	testCases := []struct {
+		name           string
+		outputBaseName string
+		importPath     string
+		outputPath     string
+	}{
+		{
+			name:           "default",
+			outputBaseName: "",
+			importPath:     "k8s.io/kubernetes/pkg/apis/testgroup/generated.proto",
+			outputPath:     "k8s.io/kubernetes/pkg/apis/testgroup/generated.pb.go",
+		},
+		{
+			name:           "custom",
+			outputBaseName: "api",
+			importPath:     "k8s.io/kubernetes/pkg/apis/testgroup/api.proto",
+			outputPath:     "k8s.io/kubernetes/pkg/apis/testgroup/api.pb.go",
+		},
+	}
+	for _, test := range testCases {
+		pkg := newProtobufPackage(
+			"k8s.io/kubernetes/pkg/apis/testgroup",
+			"k8s.io.kubernetes.pkg.apis.testgroup",
+			false,
+			nil,
+			test.outputBaseName,
+			"",
+		)
+		if e, a := test.importPath, pkg.ImportPath(); e != a {
+			t.Errorf("%s: expected import path %q, got %q", test.name, e, a)
+		}
+		if e, a := test.outputPath, pkg.OutputPath(); e != a {
+			t.Errorf("%s: expected output path %q, got %q", test.name, e, a)
+		}
+	}
+}
+
+func TestHeaderCommand(t *testing.T) {
+	// without a header command the header is stable across machines
+	pkg := newProtobufPackage("k8s.io/kubernetes/pkg/apis/testgroup", "k8s.io.kubernetes.pkg.apis.testgroup", false, nil, "", "")
+	header := string(pkg.HeaderText)
+	if !strings.Contains(header, "Do not edit it manually!") {
+		t.Errorf("missing autogenerated notice: %q", header)
+	}
+	if strings.Contains(header, "$") || strings.Contains(header, "/home/") {
+		t.Errorf("header without a command must contain no machine-specific content: %q", header)
+	}
+
+	// opting in records the command verbatim after the notice
+	pkg = newProtobufPackage("k8s.io/kubernetes/pkg/apis/testgroup", "k8s.io.kubernetes.pkg.apis.testgroup", false, nil, "", "/home/user/bin/go-to-protobuf --packages=foo")
+	header = string(pkg.HeaderText)
+	if !strings.Contains(header, "Do not edit it manually!") {
+		t.Errorf("missing autogenerated notice: %q", header)
+	}
+	if !strings.Contains(header, "// $ /home/user/bin/go-to-protobuf --packages=foo") {
+		t.Errorf("missing command line: %q", header)
+	}
+}
+
+// multiNameTypeSpec returns a struct declaration with one field declaring two
+// names under a single protobuf tag and one well-formed field.
+func multiNameTypeSpec() *ast.TypeSpec {
+	return &ast.TypeSpec{
+		Name: ast.NewIdent("Frobber"),
+		Type: &ast.StructType{
+			Fields: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("A"), ast.NewIdent("B")},
+						Tag:   &ast.BasicLit{Kind: token.STRING, Value: "`protobuf:\"bytes,1,opt,name=a\"`"},
+					},
+					{
+						Names: []*ast.Ident{ast.NewIdent("C")},
+						Tag:   &ast.BasicLit{Kind: token.STRING, Value: "`protobuf:\"bytes,2,opt,name=c\"`"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestExtractGeneratedTypeMultiNameLenient(t *testing.T) {
+	pkg := newProtobufPackage("k8s.io/kubernetes/pkg/apis/testgroup", "k8s.io.kubernetes.pkg.apis.testgroup", false, nil, "", "")
+	pkg.LocalNames = map[string]struct{}{"Frobber": {}}
+
+	if !pkg.ExtractGeneratedType(multiNameTypeSpec()) {
+		t.Fatalf("expected the type to be extracted")
+	}
+	if errs := pkg.ExtractErrors(); len(errs) != 0 {
+		t.Fatalf("unexpected errors in lenient mode: %v", errs)
+	}
+	tags := pkg.StructTags["Frobber"]
+	if _, ok := tags["A"]; ok {
+		t.Errorf("expected the multi-name field to be skipped, got %v", tags)
+	}
+	if _, ok := tags["C"]; !ok {
+		t.Errorf("expected the well-formed field to keep its tag, got %v", tags)
+	}
+}
+
+func TestExtractGeneratedTypeMultiNameStrict(t *testing.T) {
+	pkg := newProtobufPackage("k8s.io/kubernetes/pkg/apis/testgroup", "k8s.io.kubernetes.pkg.apis.testgroup", false, nil, "", "")
+	pkg.LocalNames = map[string]struct{}{"Frobber": {}}
+	pkg.StrictTags = true
+
+	pkg.ExtractGeneratedType(multiNameTypeSpec())
+	if errs := pkg.ExtractErrors(); len(errs) != 1 {
+		t.Fatalf("expected one error in strict mode, got %v", errs)
+	}
+	if _, ok := pkg.StructTags["Frobber"]["A"]; ok {
+		t.Errorf("expected no tag to be stored for the multi-name field, got %v", pkg.StructTags["Frobber"])
+	}
+}
+
+func TestDeniedPackages(t *testing.T) {
+	denied := map[string]struct{}{"k8s.io/kubernetes/pkg/opaque": {}}
+
+	pkg := newProtobufPackage("k8s.io/kubernetes/pkg/apis/testgroup", "k8s.io.kubernetes.pkg.apis.testgroup", false, nil, "", "")
+	pkg.DeniedPackages = denied
+	deniedType := &types.Type{
+		Name: types.Name{Package: "k8s.io/kubernetes/pkg/opaque", Name: "Blob"},
+		Kind: types.Struct,
+	}
+	pkg.FilterTypes = map[types.Name]struct{}{deniedType.Name: {}}
+	if pkg.filterFunc(nil, deniedType) {
+		t.Errorf("expected a type from a denied package to be rejected by the filter")
+	}
+
+	tracker := NewImportTracker(pkg.ProtoTypeName())
+	tracker.Deny(denied)
+	tracker.AddType(&types.Type{
+		Kind: types.Protobuf,
+		Name: types.Name{
+			Name:    "Blob",
+			Package: "k8s.io.kubernetes.pkg.opaque",
+			Path:    "k8s.io/kubernetes/pkg/opaque/generated.proto",
+		},
+	})
+	if lines := tracker.ImportLines(); len(lines) != 0 {
+		t.Errorf("expected no imports from a denied package, got %v", lines)
+	}
+	tracker.AddType(&types.Type{
+		Kind: types.Protobuf,
+		Name: types.Name{
+			Name:    "Quantity",
+			Package: "k8s.io.apimachinery.pkg.api.resource",
+			Path:    "k8s.io/apimachinery/pkg/api/resource/generated.proto",
+		},
+	})
+	if lines := tracker.ImportLines(); len(lines) != 1 {
+		t.Errorf("expected the import of an allowed package to be kept, got %v", lines)
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"k8s.io/gengo/types"
+)
+
+// serviceTag is the comment marker requesting proto service generation for a
+// Go interface type. A value other than "true" overrides the name of the
+// generated service:
+//
+//	+protobuf.service=PodStore
+const serviceTag = "protobuf.service"
+
+// ServiceSpec describes a proto service to generate from a Go interface. Each
+// method of the interface becomes an rpc entry; methods must accept exactly
+// one request message type and return exactly one response message type.
+type ServiceSpec struct {
+	// Name is the name of the generated proto service. If empty, the name of
+	// the Go interface is used.
+	Name string
+	// Interface is the name of a Go interface within the package whose
+	// methods define the rpc entries of the service.
+	Interface string
+}
+
+// serviceSpecFromComments returns the ServiceSpec requested by a
+// +protobuf.service marker on the given interface type, if any.
+func serviceSpecFromComments(t *types.Type) (ServiceSpec, bool) {
+	if t == nil || t.Kind != types.Interface {
+		return ServiceSpec{}, false
+	}
+	values := types.ExtractCommentTags("+", t.CommentLines)[serviceTag]
+	if values == nil {
+		return ServiceSpec{}, false
+	}
+	spec := ServiceSpec{Interface: t.Name.Name}
+	if len(values[0]) != 0 && values[0] != "true" {
+		spec.Name = values[0]
+	}
+	return spec, true
+}
+
+// discoverServices merges the configured specs with those requested by
+// +protobuf.service markers on interface types in the package, keeping the
+// result in a stable order. Explicitly configured specs take precedence over
+// markers naming the same interface.
+func discoverServices(configured []ServiceSpec, pkg *types.Package) []ServiceSpec {
+	specs := append([]ServiceSpec{}, configured...)
+	if pkg == nil {
+		return specs
+	}
+	seen := make(map[string]struct{}, len(configured))
+	for _, s := range configured {
+		seen[s.Interface] = struct{}{}
+	}
+	names := make([]string, 0, len(pkg.Types))
+	for name := range pkg.Types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		spec, ok := serviceSpecFromComments(pkg.Types[name])
+		if !ok {
+			continue
+		}
+		if _, dup := seen[spec.Interface]; dup {
+			continue
+		}
+		specs = append(specs, spec)
+	}
+	return specs
+}
+
+// renderService writes a proto service definition generated from the methods
+// of the provided Go interface type. It returns an error if the type is not
+// an interface or if any method's parameter or return type cannot be
+// represented as a generatable message.
+func renderService(out io.Writer, name string, t *types.Type, locator ProtobufLocator) error {
+	if t == nil || t.Kind != types.Interface {
+		return fmt.Errorf("service %s: type %v is not an interface", name, t)
+	}
+	methodNames := make([]string, 0, len(t.Methods))
+	for methodName := range t.Methods {
+		methodNames = append(methodNames, methodName)
+	}
+	sort.Strings(methodNames)
+
+	fmt.Fprintf(out, "service %s {\n", name)
+	for _, methodName := range methodNames {
+		sig := t.Methods[methodName].Signature
+		if sig == nil || len(sig.Parameters) != 1 || len(sig.Results) != 1 {
+			return fmt.Errorf("service %s: method %s must accept exactly one request type and return exactly one response type", name, methodName)
+		}
+		request, err := serviceMessageFor(locator, sig.Parameters[0])
+		if err != nil {
+			return fmt.Errorf("service %s: method %s: request type is not a generatable message: %v", name, methodName, err)
+		}
+		response, err := serviceMessageFor(locator, sig.Results[0])
+		if err != nil {
+			return fmt.Errorf("service %s: method %s: response type is not a generatable message: %v", name, methodName, err)
+		}
+		fmt.Fprintf(out, "  rpc %s(%s) returns (%s);\n", methodName, request.Name.Name, response.Name.Name)
+	}
+	fmt.Fprintf(out, "}\n\n")
+	return nil
+}
+
+// serviceMessageFor resolves the proto message for a method parameter or
+// result, unwrapping a pointer if necessary.
+func serviceMessageFor(locator ProtobufLocator, t *types.Type) (*types.Type, error) {
+	if t.Kind == types.Pointer {
+		t = t.Elem
+	}
+	if t.Kind != types.Struct {
+		return nil, errUnrecognizedType
+	}
+	return locator.ProtoTypeFor(t)
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protobuf
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"k8s.io/gengo/types"
+)
+
+type fakeServiceLocator struct{}
+
+func (fakeServiceLocator) ProtoTypeFor(t *types.Type) (*types.Type, error) {
+	if t.Kind != types.Struct {
+		return nil, errUnrecognizedType
+	}
+	return &types.Type{Name: t.Name, Kind: types.Protobuf}, nil
+}
+
+func (fakeServiceLocator) GoTypeForName(name types.Name) *types.Type { return nil }
+
+func (fakeServiceLocator) CastTypeName(name types.Name) string { return name.Name }
+
+func TestRenderService(t *testing.T) {
+	request := &types.Type{Name: types.Name{Package: "foo", Name: "GetRequest"}, Kind: types.Struct}
+	response := &types.Type{Name: types.Name{Package: "foo", Name: "GetResponse"}, Kind: types.Struct}
+	iface := &types.Type{
+		Name: types.Name{Package: "foo", Name: "PodStore"},
+		Kind: types.Interface,
+		Methods: map[string]*types.Type{
+			"Get": {
+				Kind: types.Func,
+				Signature: &types.Signature{
+					Parameters: []*types.Type{request},
+					Results:    []*types.Type{response},
+				},
+			},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+	if err := renderService(buf, "PodStore", iface, fakeServiceLocator{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "service PodStore {") {
+		t.Errorf("missing service definition: %s", out)
+	}
+	if !strings.Contains(out, "rpc Get(GetRequest) returns (GetResponse);") {
+		t.Errorf("missing rpc entry: %s", out)
+	}
+
+	// a method whose parameter is not a message must be rejected
+	badIface := &types.Type{
+		Name: types.Name{Package: "foo", Name: "Bad"},
+		Kind: types.Interface,
+		Methods: map[string]*types.Type{
+			"Get": {
+				Kind: types.Func,
+				Signature: &types.Signature{
+					Parameters: []*types.Type{{Name: types.Name{Name: "string"}, Kind: types.Builtin}},
+					Results:    []*types.Type{response},
+				},
+			},
+		},
+	}
+	if err := renderService(&bytes.Buffer{}, "Bad", badIface, fakeServiceLocator{}); err == nil {
+		t.Errorf("expected error for non-message request type")
+	}
+}
+
+func TestDiscoverServices(t *testing.T) {
+	request := &types.Type{Name: types.Name{Package: "foo", Name: "GetRequest"}, Kind: types.Struct}
+	response := &types.Type{Name: types.Name{Package: "foo", Name: "GetResponse"}, Kind: types.Struct}
+	marked := &types.Type{
+		Name:         types.Name{Package: "foo", Name: "PodStore"},
+		Kind:         types.Interface,
+		CommentLines: []string{"+protobuf.service=Pods"},
+		Methods: map[string]*types.Type{
+			"Get": {
+				Kind: types.Func,
+				Signature: &types.Signature{
+					Parameters: []*types.Type{request},
+					Results:    []*types.Type{response},
+				},
+			},
+		},
+	}
+	pkg := &types.Package{
+		Types: map[string]*types.Type{
+			"PodStore":    marked,
+			"GetRequest":  request,
+			"GetResponse": response,
+			// an unmarked interface is not picked up
+			"NodeStore": {Name: types.Name{Package: "foo", Name: "NodeStore"}, Kind: types.Interface},
+		},
+	}
+
+	specs := discoverServices(nil, pkg)
+	if len(specs) != 1 {
+		t.Fatalf("expected one discovered service, got %v", specs)
+	}
+	if specs[0].Name != "Pods" || specs[0].Interface != "PodStore" {
+		t.Errorf("unexpected spec from marker: %#v", specs[0])
+	}
+
+	buf := &bytes.Buffer{}
+	if err := renderService(buf, specs[0].Name, marked, fakeServiceLocator{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "service Pods {") {
+		t.Errorf("missing service definition: %s", buf.String())
+	}
+
+	// explicit configuration wins over a marker naming the same interface
+	specs = discoverServices([]ServiceSpec{{Name: "Explicit", Interface: "PodStore"}}, pkg)
+	if len(specs) != 1 || specs[0].Name != "Explicit" {
+		t.Errorf("expected the configured spec to take precedence, got %v", specs)
+	}
+}
+
+func TestServiceSpecFromComments(t *testing.T) {
+	bare := &types.Type{
+		Name:         types.Name{Package: "foo", Name: "PodStore"},
+		Kind:         types.Interface,
+		CommentLines: []string{"+protobuf.service=true"},
+	}
+	spec, ok := serviceSpecFromComments(bare)
+	if !ok {
+		t.Fatalf("expected a spec for a marked interface")
+	}
+	if spec.Name != "" || spec.Interface != "PodStore" {
+		t.Errorf("expected the interface name to be used, got %#v", spec)
+	}
+
+	// the marker is ignored on non-interface types
+	if _, ok := serviceSpecFromComments(&types.Type{
+		Name:         types.Name{Package: "foo", Name: "NotAnInterface"},
+		Kind:         types.Struct,
+		CommentLines: []string{"+protobuf.service=true"},
+	}); ok {
+		t.Errorf("expected no spec for a struct type")
+	}
+}
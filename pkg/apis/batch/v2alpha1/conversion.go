@@ -19,6 +19,7 @@ package v2alpha1
 import (
 	"fmt"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/conversion"
 	"k8s.io/apimachinery/pkg/runtime"
 	v1 "k8s.io/kubernetes/pkg/api/v1"
@@ -30,6 +31,7 @@ func addConversionFuncs(scheme *runtime.Scheme) error {
 	err := scheme.AddConversionFuncs(
 		Convert_batch_JobSpec_To_v2alpha1_JobSpec,
 		Convert_v2alpha1_JobSpec_To_batch_JobSpec,
+		Convert_v2alpha1_ScheduledJob_To_batch_CronJob,
 	)
 	if err != nil {
 		return err
@@ -54,11 +56,49 @@ func addConversionFuncs(scheme *runtime.Scheme) error {
 	return nil
 }
 
+// Convert_v2alpha1_ScheduledJob_To_batch_CronJob applies ScheduledJob
+// defaults before delegating to the generated CronJob conversion, so an unset
+// concurrencyPolicy or suspend never reaches the internal type.  The input is
+// not mutated and the internal→versioned path is left untouched, so explicit
+// values round-trip unchanged.
+func Convert_v2alpha1_ScheduledJob_To_batch_CronJob(in *CronJob, out *batch.CronJob, s conversion.Scope) error {
+	defaulted := *in
+	SetDefaults_ScheduledJob(&defaulted)
+	return Convert_v2alpha1_CronJob_To_batch_CronJob(&defaulted, out, s)
+}
+
+// deepCopyLabelSelector copies the selector including the MatchLabels map and
+// the Values slice of every requirement, so the versioned and internal forms
+// never share backing arrays.
+func deepCopyLabelSelector(in *metav1.LabelSelector) *metav1.LabelSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(metav1.LabelSelector)
+	if in.MatchLabels != nil {
+		out.MatchLabels = make(map[string]string, len(in.MatchLabels))
+		for key, value := range in.MatchLabels {
+			out.MatchLabels[key] = value
+		}
+	}
+	if in.MatchExpressions != nil {
+		out.MatchExpressions = make([]metav1.LabelSelectorRequirement, len(in.MatchExpressions))
+		for i, requirement := range in.MatchExpressions {
+			out.MatchExpressions[i] = requirement
+			if requirement.Values != nil {
+				out.MatchExpressions[i].Values = make([]string, len(requirement.Values))
+				copy(out.MatchExpressions[i].Values, requirement.Values)
+			}
+		}
+	}
+	return out
+}
+
 func Convert_batch_JobSpec_To_v2alpha1_JobSpec(in *batch.JobSpec, out *JobSpec, s conversion.Scope) error {
 	out.Parallelism = in.Parallelism
 	out.Completions = in.Completions
 	out.ActiveDeadlineSeconds = in.ActiveDeadlineSeconds
-	out.Selector = in.Selector
+	out.Selector = deepCopyLabelSelector(in.Selector)
 	if in.ManualSelector != nil {
 		out.ManualSelector = new(bool)
 		*out.ManualSelector = *in.ManualSelector
@@ -76,7 +116,7 @@ func Convert_v2alpha1_JobSpec_To_batch_JobSpec(in *JobSpec, out *batch.JobSpec,
 	out.Parallelism = in.Parallelism
 	out.Completions = in.Completions
 	out.ActiveDeadlineSeconds = in.ActiveDeadlineSeconds
-	out.Selector = in.Selector
+	out.Selector = deepCopyLabelSelector(in.Selector)
 	if in.ManualSelector != nil {
 		out.ManualSelector = new(bool)
 		*out.ManualSelector = *in.ManualSelector
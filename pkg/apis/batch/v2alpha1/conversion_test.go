@@ -0,0 +1,232 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v2alpha1_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apitesting "k8s.io/apimachinery/pkg/api/testing"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/diff"
+	"k8s.io/kubernetes/pkg/api"
+	_ "k8s.io/kubernetes/pkg/api/install"
+	kapitesting "k8s.io/kubernetes/pkg/api/testing"
+	apiv1 "k8s.io/kubernetes/pkg/api/v1"
+	"k8s.io/kubernetes/pkg/apis/batch"
+	_ "k8s.io/kubernetes/pkg/apis/batch/install"
+	. "k8s.io/kubernetes/pkg/apis/batch/v2alpha1"
+)
+
+func TestConvertScheduledJobDefaults(t *testing.T) {
+	original := &CronJob{}
+	internal := &batch.CronJob{}
+	if err := Convert_v2alpha1_ScheduledJob_To_batch_CronJob(original, internal, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if internal.Spec.ConcurrencyPolicy != batch.AllowConcurrent {
+		t.Errorf("expected unset concurrency policy to default to %q, got %q", batch.AllowConcurrent, internal.Spec.ConcurrencyPolicy)
+	}
+	if internal.Spec.Suspend == nil || *internal.Spec.Suspend {
+		t.Errorf("expected unset suspend to default to false, got %v", internal.Spec.Suspend)
+	}
+	if original.Spec.ConcurrencyPolicy != "" {
+		t.Errorf("conversion mutated its input: %q", original.Spec.ConcurrencyPolicy)
+	}
+
+	// explicit values survive the conversion unchanged
+	explicit := &CronJob{}
+	explicit.Spec.ConcurrencyPolicy = ForbidConcurrent
+	internal = &batch.CronJob{}
+	if err := Convert_v2alpha1_ScheduledJob_To_batch_CronJob(explicit, internal, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if internal.Spec.ConcurrencyPolicy != batch.ForbidConcurrent {
+		t.Errorf("expected explicit concurrency policy to be preserved, got %q", internal.Spec.ConcurrencyPolicy)
+	}
+}
+
+// TestJobRoundTripConversion converts fuzzed internal Jobs to v2alpha1 and
+// back, catching conversions that silently drop fields.
+func TestJobRoundTripConversion(t *testing.T) {
+	fuzzer := apitesting.FuzzerFor(kapitesting.FuzzerFuncs(t, api.Codecs), rand.NewSource(352))
+	for i := 0; i < 20; i++ {
+		internal := &batch.Job{}
+		fuzzer.Fuzz(internal)
+		// Kind and apiVersion are owned by the codec, not the conversion.
+		internal.TypeMeta = metav1.TypeMeta{}
+
+		versioned := &Job{}
+		if err := api.Scheme.Convert(internal, versioned, nil); err != nil {
+			t.Fatalf("unexpected conversion error: %v", err)
+		}
+		roundTripped := &batch.Job{}
+		if err := api.Scheme.Convert(versioned, roundTripped, nil); err != nil {
+			t.Fatalf("unexpected conversion error: %v", err)
+		}
+		if !apiequality.Semantic.DeepEqual(internal, roundTripped) {
+			t.Fatalf("Job did not round trip: %s", diff.ObjectDiff(internal, roundTripped))
+		}
+	}
+}
+
+func TestJobSpecRoundTripConversion(t *testing.T) {
+	internal := &batch.Job{
+		Spec: batch.JobSpec{
+			Parallelism:           newInt32(2),
+			Completions:           newInt32(5),
+			ActiveDeadlineSeconds: func(val int64) *int64 { return &val }(600),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"job": "roundtrip"},
+			},
+			ManualSelector: func(val bool) *bool { return &val }(true),
+			// The conversion from v1 always materializes a SecurityContext,
+			// so the fixture needs one for the round trip to compare equal.
+			Template: api.PodTemplateSpec{
+				Spec: api.PodSpec{SecurityContext: &api.PodSecurityContext{}},
+			},
+		},
+	}
+
+	versioned := &Job{}
+	if err := api.Scheme.Convert(internal, versioned, nil); err != nil {
+		t.Fatalf("unexpected conversion error: %v", err)
+	}
+	roundTripped := &batch.Job{}
+	if err := api.Scheme.Convert(versioned, roundTripped, nil); err != nil {
+		t.Fatalf("unexpected conversion error: %v", err)
+	}
+	if !apiequality.Semantic.DeepEqual(internal.Spec, roundTripped.Spec) {
+		t.Fatalf("JobSpec did not round trip: %s", diff.ObjectDiff(internal.Spec, roundTripped.Spec))
+	}
+}
+
+func TestJobListRoundTripConversion(t *testing.T) {
+	// As above, the pod template needs a SecurityContext since conversion
+	// from v1 always materializes one.
+	template := api.PodTemplateSpec{
+		Spec: api.PodSpec{SecurityContext: &api.PodSecurityContext{}},
+	}
+	internal := &batch.JobList{
+		Items: []batch.Job{
+			{Spec: batch.JobSpec{Parallelism: newInt32(1), Template: template}},
+			{Spec: batch.JobSpec{Completions: newInt32(3), Template: template}},
+		},
+	}
+
+	versioned := &JobList{}
+	if err := api.Scheme.Convert(internal, versioned, nil); err != nil {
+		t.Fatalf("unexpected conversion error: %v", err)
+	}
+	roundTripped := &batch.JobList{}
+	if err := api.Scheme.Convert(versioned, roundTripped, nil); err != nil {
+		t.Fatalf("unexpected conversion error: %v", err)
+	}
+	if !apiequality.Semantic.DeepEqual(internal, roundTripped) {
+		t.Fatalf("JobList did not round trip: %s", diff.ObjectDiff(internal, roundTripped))
+	}
+}
+
+// TestJobSpecSelectorConversionAliasing ensures the converted selector never
+// shares backing storage with its source across API versions.
+func TestJobSpecSelectorConversionAliasing(t *testing.T) {
+	internal := &batch.JobSpec{
+		Selector: &metav1.LabelSelector{
+			MatchLabels: map[string]string{"job": "original"},
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{
+					Key:      "tier",
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   []string{"frontend", "backend"},
+				},
+			},
+		},
+	}
+
+	versioned := &JobSpec{}
+	if err := Convert_batch_JobSpec_To_v2alpha1_JobSpec(internal, versioned, nil); err != nil {
+		t.Fatalf("unexpected conversion error: %v", err)
+	}
+	versioned.Selector.MatchLabels["job"] = "mutated"
+	versioned.Selector.MatchExpressions[0].Values[0] = "mutated"
+	if internal.Selector.MatchLabels["job"] != "original" {
+		t.Errorf("mutating the converted MatchLabels changed the source: %v", internal.Selector.MatchLabels)
+	}
+	if internal.Selector.MatchExpressions[0].Values[0] != "frontend" {
+		t.Errorf("mutating the converted Values changed the source: %v", internal.Selector.MatchExpressions[0].Values)
+	}
+
+	// and in the versioned-to-internal direction
+	roundTripped := &batch.JobSpec{}
+	if err := Convert_v2alpha1_JobSpec_To_batch_JobSpec(versioned, roundTripped, nil); err != nil {
+		t.Fatalf("unexpected conversion error: %v", err)
+	}
+	roundTripped.Selector.MatchExpressions[0].Values[0] = "mutated again"
+	if versioned.Selector.MatchExpressions[0].Values[0] != "mutated" {
+		t.Errorf("mutating the converted Values changed the source: %v", versioned.Selector.MatchExpressions[0].Values)
+	}
+}
+
+// The active-reference conversion used to go through reflection; the
+// benchmarks document the gap between scheme dispatch and the direct
+// generated path for status objects with large Active lists.
+func makeVersionedCronJobStatus(numActive int) *CronJobStatus {
+	status := &CronJobStatus{Active: make([]apiv1.ObjectReference, numActive)}
+	for i := range status.Active {
+		status.Active[i] = apiv1.ObjectReference{
+			Kind:      "Job",
+			Namespace: "default",
+			Name:      fmt.Sprintf("job-%d", i),
+		}
+	}
+	return status
+}
+
+func BenchmarkCronJobStatusConversionScheme(b *testing.B) {
+	in := makeVersionedCronJobStatus(100)
+	out := &batch.CronJobStatus{}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := api.Scheme.Convert(in, out, nil); err != nil {
+			b.Fatalf("unexpected conversion error: %v", err)
+		}
+	}
+}
+
+func BenchmarkCronJobStatusConversionDirect(b *testing.B) {
+	in := makeVersionedCronJobStatus(100)
+	out := &batch.CronJobStatus{}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := Convert_v2alpha1_CronJobStatus_To_batch_CronJobStatus(in, out, nil); err != nil {
+			b.Fatalf("unexpected conversion error: %v", err)
+		}
+	}
+}
+
+func TestCronJobStatusActiveConversion(t *testing.T) {
+	in := makeVersionedCronJobStatus(3)
+	out := &batch.CronJobStatus{}
+	if err := Convert_v2alpha1_CronJobStatus_To_batch_CronJobStatus(in, out, nil); err != nil {
+		t.Fatalf("unexpected conversion error: %v", err)
+	}
+	if len(out.Active) != 3 || out.Active[1].Name != "job-1" {
+		t.Errorf("active references were not converted: %v", out.Active)
+	}
+}
@@ -47,6 +47,14 @@ func SetDefaults_Job(obj *Job) {
 	}
 }
 
+// SetDefaults_ScheduledJob applies defaults for the legacy ScheduledJob kind,
+// which is served from the CronJob type.  It is invoked from the
+// versioned→internal conversion so the controller never sees unset critical
+// fields on objects submitted under the old kind.
+func SetDefaults_ScheduledJob(obj *CronJob) {
+	SetDefaults_CronJob(obj)
+}
+
 func SetDefaults_CronJob(obj *CronJob) {
 	if obj.Spec.ConcurrencyPolicy == "" {
 		obj.Spec.ConcurrencyPolicy = AllowConcurrent
@@ -0,0 +1,88 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extensions
+
+import (
+	"k8s.io/apimachinery/pkg/conversion"
+)
+
+// cloner handles the fields the generated deep-copy functions delegate to
+// reflection, e.g. ObjectMeta.
+var cloner = conversion.NewCloner()
+
+// DeepCopy returns a deep copy of the DaemonSet.
+func (in *DaemonSet) DeepCopy() (*DaemonSet, error) {
+	out := new(DaemonSet)
+	if err := DeepCopy_extensions_DaemonSet(in, out, cloner); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeepCopy returns a deep copy of the Deployment.
+func (in *Deployment) DeepCopy() (*Deployment, error) {
+	out := new(Deployment)
+	if err := DeepCopy_extensions_Deployment(in, out, cloner); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeepCopy returns a deep copy of the Ingress.
+func (in *Ingress) DeepCopy() (*Ingress, error) {
+	out := new(Ingress)
+	if err := DeepCopy_extensions_Ingress(in, out, cloner); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeepCopy returns a deep copy of the NetworkPolicy.
+func (in *NetworkPolicy) DeepCopy() (*NetworkPolicy, error) {
+	out := new(NetworkPolicy)
+	if err := DeepCopy_extensions_NetworkPolicy(in, out, cloner); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeepCopy returns a deep copy of the PodSecurityPolicy.
+func (in *PodSecurityPolicy) DeepCopy() (*PodSecurityPolicy, error) {
+	out := new(PodSecurityPolicy)
+	if err := DeepCopy_extensions_PodSecurityPolicy(in, out, cloner); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeepCopy returns a deep copy of the ReplicaSet.
+func (in *ReplicaSet) DeepCopy() (*ReplicaSet, error) {
+	out := new(ReplicaSet)
+	if err := DeepCopy_extensions_ReplicaSet(in, out, cloner); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeepCopy returns a deep copy of the ThirdPartyResource.
+func (in *ThirdPartyResource) DeepCopy() (*ThirdPartyResource, error) {
+	out := new(ThirdPartyResource)
+	if err := DeepCopy_extensions_ThirdPartyResource(in, out, cloner); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package extensions
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/conversion"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Status substructures with reference fields (e.g. conditions) are deep
+// copied by the generated code through their dedicated Status functions, so
+// a copy never aliases the original. DaemonSetStatus holds only value fields,
+// for which deepcopy-gen correctly emits a plain struct assignment; the
+// reflection check below pins that precondition so the assignment cannot
+// silently become a shallow copy if the type grows a reference field.
+func TestDeepCopyStatusIsolation(t *testing.T) {
+	cloner := conversion.NewCloner()
+
+	statusType := reflect.TypeOf(DaemonSetStatus{})
+	for i := 0; i < statusType.NumField(); i++ {
+		switch statusType.Field(i).Type.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Map:
+			t.Errorf("DaemonSetStatus.%s is a reference field; regenerate deepcopy and extend this test", statusType.Field(i).Name)
+		}
+	}
+
+	daemonSet := DaemonSet{
+		Status: DaemonSetStatus{NumberReady: 2},
+	}
+	daemonSetCopy := DaemonSet{}
+	if err := DeepCopy_extensions_DaemonSet(&daemonSet, &daemonSetCopy, cloner); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	daemonSetCopy.Status.NumberReady = 5
+	if daemonSet.Status.NumberReady != 2 {
+		t.Errorf("mutating the copied DaemonSet status changed the original: %v", daemonSet.Status)
+	}
+
+	deployment := Deployment{
+		Status: DeploymentStatus{
+			Conditions: []DeploymentCondition{{Type: DeploymentAvailable}},
+		},
+	}
+	deploymentCopy := Deployment{}
+	if err := DeepCopy_extensions_Deployment(&deployment, &deploymentCopy, cloner); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	deploymentCopy.Status.Conditions[0].Type = DeploymentProgressing
+	if deployment.Status.Conditions[0].Type != DeploymentAvailable {
+		t.Errorf("mutating the copied Deployment conditions changed the original: %v", deployment.Status.Conditions)
+	}
+
+	replicaSet := ReplicaSet{
+		Status: ReplicaSetStatus{
+			Conditions: []ReplicaSetCondition{{Type: ReplicaSetReplicaFailure}},
+		},
+	}
+	replicaSetCopy := ReplicaSet{}
+	if err := DeepCopy_extensions_ReplicaSet(&replicaSet, &replicaSetCopy, cloner); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	replicaSetCopy.Status.Conditions[0].Reason = "mutated"
+	if replicaSet.Status.Conditions[0].Reason != "" {
+		t.Errorf("mutating the copied ReplicaSet conditions changed the original: %v", replicaSet.Status.Conditions)
+	}
+}
+
+// IngressBackend is copied by value, which is only safe because
+// intstr.IntOrString contains no pointer or slice fields: a Go string copy
+// does not alias its source. This test pins that assumption for both the
+// default backend and the per-path backends; if IntOrString ever grows a
+// reference field, DeepCopy_extensions_IngressBackend must deep copy it.
+func TestIngressBackendServicePortDeepCopy(t *testing.T) {
+	cloner := conversion.NewCloner()
+
+	ingress := Ingress{
+		Spec: IngressSpec{
+			Backend: &IngressBackend{
+				ServiceName: "default-svc",
+				ServicePort: intstr.FromString("http"),
+			},
+			Rules: []IngressRule{
+				{
+					Host: "foo.example.com",
+					IngressRuleValue: IngressRuleValue{
+						HTTP: &HTTPIngressRuleValue{
+							Paths: []HTTPIngressPath{
+								{
+									Path: "/",
+									Backend: IngressBackend{
+										ServiceName: "path-svc",
+										ServicePort: intstr.FromString("metrics"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	ingressCopy := Ingress{}
+	if err := DeepCopy_extensions_Ingress(&ingress, &ingressCopy, cloner); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ingressCopy.Spec.Backend.ServicePort = intstr.FromString("mutated")
+	if port := ingress.Spec.Backend.ServicePort; port.StrVal != "http" {
+		t.Errorf("mutating the copied default backend port changed the original: %v", port)
+	}
+
+	ingressCopy.Spec.Rules[0].HTTP.Paths[0].Backend.ServicePort = intstr.FromInt(8080)
+	if port := ingress.Spec.Rules[0].HTTP.Paths[0].Backend.ServicePort; port.StrVal != "metrics" {
+		t.Errorf("mutating the copied path backend port changed the original: %v", port)
+	}
+}
+
+func TestTypedDeepCopy(t *testing.T) {
+	ingress := &Ingress{
+		Spec: IngressSpec{
+			Rules: []IngressRule{
+				{Host: "foo.example.com"},
+				{Host: "bar.example.com"},
+			},
+		},
+	}
+	ingressCopy, err := ingress.DeepCopy()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ingressCopy.Spec.Rules[0].Host = "mutated.example.com"
+	if ingress.Spec.Rules[0].Host != "foo.example.com" {
+		t.Errorf("mutating the copied Ingress rules changed the original: %v", ingress.Spec.Rules)
+	}
+
+	deployment := &Deployment{
+		Status: DeploymentStatus{
+			Conditions: []DeploymentCondition{{Type: DeploymentAvailable}},
+		},
+	}
+	deploymentCopy, err := deployment.DeepCopy()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	deploymentCopy.Status.Conditions[0].Type = DeploymentProgressing
+	if deployment.Status.Conditions[0].Type != DeploymentAvailable {
+		t.Errorf("mutating the copied Deployment conditions changed the original: %v", deployment.Status.Conditions)
+	}
+}
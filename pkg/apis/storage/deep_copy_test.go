@@ -0,0 +1,78 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"testing"
+)
+
+func TestStorageClassDeepCopy(t *testing.T) {
+	class := &StorageClass{
+		Provisioner: "kubernetes.io/gce-pd",
+		Parameters:  map[string]string{"type": "pd-ssd"},
+	}
+	classCopy, err := class.DeepCopy()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	classCopy.Parameters["type"] = "pd-standard"
+	if class.Parameters["type"] != "pd-ssd" {
+		t.Errorf("mutating the copied parameters changed the original: %v", class.Parameters)
+	}
+}
+
+// storageClassFixture stands in for a future StorageClass revision with a
+// []string field such as mountOptions, verifying that routing the field
+// through deepCopyStringSlice keeps the copy independent.
+type storageClassFixture struct {
+	StorageClass
+	MountOptions []string
+}
+
+func (in *storageClassFixture) deepCopy() (*storageClassFixture, error) {
+	class, err := in.StorageClass.DeepCopy()
+	if err != nil {
+		return nil, err
+	}
+	return &storageClassFixture{
+		StorageClass: *class,
+		MountOptions: deepCopyStringSlice(in.MountOptions),
+	}, nil
+}
+
+func TestDeepCopyStringSlice(t *testing.T) {
+	fixture := &storageClassFixture{
+		StorageClass: StorageClass{Provisioner: "kubernetes.io/aws-ebs"},
+		MountOptions: []string{"ro", "soft"},
+	}
+	fixtureCopy, err := fixture.deepCopy()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fixtureCopy.MountOptions[0] = "rw"
+	if fixture.MountOptions[0] != "ro" {
+		t.Errorf("mutating the copied mount options changed the original: %v", fixture.MountOptions)
+	}
+
+	if deepCopyStringSlice(nil) != nil {
+		t.Errorf("expected nil to copy as nil")
+	}
+	empty := deepCopyStringSlice([]string{})
+	if empty == nil || len(empty) != 0 {
+		t.Errorf("expected empty slice to copy as empty, got %v", empty)
+	}
+}
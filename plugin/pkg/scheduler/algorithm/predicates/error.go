@@ -28,6 +28,7 @@ var (
 	ErrDiskConflict              = newPredicateFailureError("NoDiskConflict")
 	ErrVolumeZoneConflict        = newPredicateFailureError("NoVolumeZoneConflict")
 	ErrNodeSelectorNotMatch      = newPredicateFailureError("MatchNodeSelector")
+	ErrNodeAffinityNotMatch      = newPredicateFailureError("MatchNodeAffinity")
 	ErrPodAffinityNotMatch       = newPredicateFailureError("MatchInterPodAffinity")
 	ErrTaintsTolerationsNotMatch = newPredicateFailureError("PodToleratesNodeTaints")
 	ErrPodNotMatchHostName       = newPredicateFailureError("HostName")
@@ -37,6 +38,9 @@ var (
 	ErrMaxVolumeCountExceeded    = newPredicateFailureError("MaxVolumeCount")
 	ErrNodeUnderMemoryPressure   = newPredicateFailureError("NodeUnderMemoryPressure")
 	ErrNodeUnderDiskPressure     = newPredicateFailureError("NodeUnderDiskPressure")
+	// ErrNodeExcluded is a synthetic reason recorded for nodes the scheduler
+	// was configured to skip entirely.
+	ErrNodeExcluded = newPredicateFailureError("NodeExcluded")
 	// ErrFakePredicate is used for test only. The fake predicates returning false also returns error
 	// as ErrFakePredicate.
 	ErrFakePredicate = newPredicateFailureError("FakePredicateError")
@@ -552,6 +552,27 @@ func PodFitsResources(pod *v1.Pod, meta interface{}, nodeInfo *schedulercache.No
 	return len(predicateFails) == 0, predicateFails, nil
 }
 
+// NewMaxPodsPredicate creates a predicate that rejects a node once the number
+// of pods it is running has reached maxPods. A maxPods of zero or less defers
+// to the node's own pod capacity, so the predicate can be registered with a
+// cluster-wide cap or left to honor per-node limits.
+func NewMaxPodsPredicate(maxPods int) algorithm.FitPredicate {
+	return func(pod *v1.Pod, meta interface{}, nodeInfo *schedulercache.NodeInfo) (bool, []algorithm.PredicateFailureReason, error) {
+		node := nodeInfo.Node()
+		if node == nil {
+			return false, nil, fmt.Errorf("node not found")
+		}
+		allowedPodNumber := maxPods
+		if allowedPodNumber <= 0 {
+			allowedPodNumber = nodeInfo.AllowedPodNumber()
+		}
+		if len(nodeInfo.Pods())+1 > allowedPodNumber {
+			return false, []algorithm.PredicateFailureReason{NewInsufficientResourceError(v1.ResourcePods, 1, int64(len(nodeInfo.Pods())), int64(allowedPodNumber))}, nil
+		}
+		return true, nil, nil
+	}
+}
+
 // nodeMatchesNodeSelectorTerms checks if a node's labels satisfy a list of node selector terms,
 // terms are ORed, and an empty list of terms will match nothing.
 func nodeMatchesNodeSelectorTerms(node *v1.Node, nodeSelectorTerms []v1.NodeSelectorTerm) bool {
@@ -625,6 +646,45 @@ func PodSelectorMatches(pod *v1.Pod, meta interface{}, nodeInfo *schedulercache.
 	return false, []algorithm.PredicateFailureReason{ErrNodeSelectorNotMatch}, nil
 }
 
+// PodMatchNodeAffinity checks the pod's requiredDuringSchedulingIgnoredDuringExecution
+// node affinity terms against the node's labels. Terms are ORed together
+// while the expressions within a term are ANDed. Pods without required node
+// affinity fit any node.
+func PodMatchNodeAffinity(pod *v1.Pod, meta interface{}, nodeInfo *schedulercache.NodeInfo) (bool, []algorithm.PredicateFailureReason, error) {
+	node := nodeInfo.Node()
+	if node == nil {
+		return false, nil, fmt.Errorf("node not found")
+	}
+	affinity := pod.Spec.Affinity
+	if affinity == nil || affinity.NodeAffinity == nil || affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return true, nil, nil
+	}
+	terms := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	if nodeMatchesNodeSelectorTerms(node, terms) {
+		return true, nil, nil
+	}
+	return false, []algorithm.PredicateFailureReason{ErrNodeAffinityNotMatch}, nil
+}
+
+// PodMatchNodeSelector checks that the pod's NodeSelector is a subset of the
+// node's labels. Unlike PodSelectorMatches it does not evaluate node
+// affinity, so it can be used when only label matching is wanted.
+func PodMatchNodeSelector(pod *v1.Pod, meta interface{}, nodeInfo *schedulercache.NodeInfo) (bool, []algorithm.PredicateFailureReason, error) {
+	node := nodeInfo.Node()
+	if node == nil {
+		return false, nil, fmt.Errorf("node not found")
+	}
+	// an empty selector matches all nodes
+	if len(pod.Spec.NodeSelector) == 0 {
+		return true, nil, nil
+	}
+	selector := labels.SelectorFromSet(pod.Spec.NodeSelector)
+	if selector.Matches(labels.Set(node.Labels)) {
+		return true, nil, nil
+	}
+	return false, []algorithm.PredicateFailureReason{ErrNodeSelectorNotMatch}, nil
+}
+
 func PodFitsHost(pod *v1.Pod, meta interface{}, nodeInfo *schedulercache.NodeInfo) (bool, []algorithm.PredicateFailureReason, error) {
 	if len(pod.Spec.NodeName) == 0 {
 		return true, nil, nil
@@ -970,6 +1030,46 @@ func getPodAntiAffinityTerms(podAntiAffinity *v1.PodAntiAffinity) (terms []v1.Po
 	return terms
 }
 
+// NodeLocalPodAntiAffinityMatches is a lightweight anti-affinity predicate
+// that only consults the pods already assigned to the node being checked, so
+// it needs no pod lister or extra API calls. A node is rejected when it
+// carries a term's topology key and already runs a pod matching that term's
+// label selector. Because only the candidate node is inspected, it enforces
+// node-scoped topologies such as kubernetes.io/hostname but not cluster-wide
+// ones; use the full inter-pod affinity predicate for those.
+func NodeLocalPodAntiAffinityMatches(pod *v1.Pod, meta interface{}, nodeInfo *schedulercache.NodeInfo) (bool, []algorithm.PredicateFailureReason, error) {
+	node := nodeInfo.Node()
+	if node == nil {
+		return false, nil, fmt.Errorf("node not found")
+	}
+	affinity := pod.Spec.Affinity
+	if affinity == nil || affinity.PodAntiAffinity == nil {
+		return true, nil, nil
+	}
+	for _, term := range getPodAntiAffinityTerms(affinity.PodAntiAffinity) {
+		if len(term.TopologyKey) == 0 {
+			continue
+		}
+		topologyValue, ok := node.Labels[term.TopologyKey]
+		if !ok {
+			continue
+		}
+		namespaces := priorityutil.GetNamespacesFromPodAffinityTerm(pod, &term)
+		selector, err := metav1.LabelSelectorAsSelector(term.LabelSelector)
+		if err != nil {
+			return false, nil, err
+		}
+		for _, existingPod := range nodeInfo.Pods() {
+			if priorityutil.PodMatchesTermsNamespaceAndSelector(existingPod, namespaces, selector) {
+				reason := NewFailureReason(fmt.Sprintf("existing pod %s/%s matches anti-affinity in topology %s=%s",
+					existingPod.Namespace, existingPod.Name, term.TopologyKey, topologyValue))
+				return false, []algorithm.PredicateFailureReason{reason}, nil
+			}
+		}
+	}
+	return true, nil, nil
+}
+
 func getMatchingAntiAffinityTerms(pod *v1.Pod, nodeInfoMap map[string]*schedulercache.NodeInfo) ([]matchingPodAntiAffinityTerm, error) {
 	allNodeNames := make([]string, 0, len(nodeInfoMap))
 	for name := range nodeInfoMap {
@@ -1158,13 +1258,23 @@ func PodToleratesNodeTaints(pod *v1.Pod, meta interface{}, nodeInfo *schedulerca
 		return false, nil, err
 	}
 
-	if v1.TolerationsTolerateTaintsWithFilter(tolerations, taints, func(t *v1.Taint) bool {
+	for i := range taints {
+		taint := &taints[i]
 		// PodToleratesNodeTaints is only interested in NoSchedule and NoExecute taints.
-		return t.Effect == v1.TaintEffectNoSchedule || t.Effect == v1.TaintEffectNoExecute
-	}) {
-		return true, nil, nil
+		if taint.Effect != v1.TaintEffectNoSchedule && taint.Effect != v1.TaintEffectNoExecute {
+			continue
+		}
+		if v1.TolerationsTolerateTaint(tolerations, taint) {
+			continue
+		}
+		// Name the offending taint so the scheduler's failure message tells the
+		// user which taint the pod would need to tolerate.
+		return false, []algorithm.PredicateFailureReason{
+			ErrTaintsTolerationsNotMatch,
+			NewFailureReason(fmt.Sprintf("node has untolerated taint %q", taint.Key)),
+		}, nil
 	}
-	return false, []algorithm.PredicateFailureReason{ErrTaintsTolerationsNotMatch}, nil
+	return true, nil, nil
 }
 
 // Determine if a pod is scheduled with best-effort QoS
@@ -19,6 +19,7 @@ package predicates
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -396,6 +397,63 @@ func TestPodFitsResources(t *testing.T) {
 	}
 }
 
+func TestMaxPodsPredicate(t *testing.T) {
+	tests := []struct {
+		pod      *v1.Pod
+		nodeInfo *schedulercache.NodeInfo
+		maxPods  int
+		fits     bool
+		reasons  []algorithm.PredicateFailureReason
+		test     string
+	}{
+		{
+			pod:      &v1.Pod{},
+			nodeInfo: schedulercache.NewNodeInfo(newResourcePod(), newResourcePod()),
+			maxPods:  3,
+			fits:     true,
+			test:     "one below the cap fits",
+		},
+		{
+			pod:      &v1.Pod{},
+			nodeInfo: schedulercache.NewNodeInfo(newResourcePod(), newResourcePod(), newResourcePod()),
+			maxPods:  3,
+			fits:     false,
+			reasons:  []algorithm.PredicateFailureReason{NewInsufficientResourceError(v1.ResourcePods, 1, 3, 3)},
+			test:     "at the cap does not fit",
+		},
+		{
+			pod:      &v1.Pod{},
+			nodeInfo: schedulercache.NewNodeInfo(newResourcePod()),
+			maxPods:  0,
+			fits:     true,
+			test:     "no cap falls back to node capacity",
+		},
+		{
+			pod:      &v1.Pod{},
+			nodeInfo: schedulercache.NewNodeInfo(newResourcePod(), newResourcePod()),
+			maxPods:  0,
+			fits:     false,
+			reasons:  []algorithm.PredicateFailureReason{NewInsufficientResourceError(v1.ResourcePods, 1, 2, 2)},
+			test:     "node capacity reached",
+		},
+	}
+	for _, test := range tests {
+		node := v1.Node{Status: v1.NodeStatus{Capacity: v1.ResourceList{}, Allocatable: makeAllocatableResources(10, 20, 0, 2, 0)}}
+		test.nodeInfo.SetNode(&node)
+		pred := NewMaxPodsPredicate(test.maxPods)
+		fits, reasons, err := pred(test.pod, PredicateMetadata(test.pod, nil), test.nodeInfo)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.test, err)
+		}
+		if !fits && !reflect.DeepEqual(reasons, test.reasons) {
+			t.Errorf("%s: unexpected failure reasons: %v, want: %v", test.test, reasons, test.reasons)
+		}
+		if fits != test.fits {
+			t.Errorf("%s: expected: %v got %v", test.test, test.fits, fits)
+		}
+	}
+}
+
 func TestPodFitsHost(t *testing.T) {
 	tests := []struct {
 		pod  *v1.Pod
@@ -1298,6 +1356,278 @@ func TestPodFitsSelector(t *testing.T) {
 	}
 }
 
+func TestPodMatchNodeSelector(t *testing.T) {
+	tests := []struct {
+		pod    *v1.Pod
+		labels map[string]string
+		fits   bool
+		test   string
+	}{
+		{
+			pod:  &v1.Pod{},
+			fits: true,
+			test: "no selector always fits",
+		},
+		{
+			pod: &v1.Pod{
+				Spec: v1.PodSpec{
+					NodeSelector: map[string]string{
+						"foo": "bar",
+					},
+				},
+			},
+			labels: map[string]string{
+				"foo": "bar",
+				"baz": "blah",
+			},
+			fits: true,
+			test: "selector is a subset of the node's labels",
+		},
+		{
+			pod: &v1.Pod{
+				Spec: v1.PodSpec{
+					NodeSelector: map[string]string{
+						"foo": "bar",
+					},
+				},
+			},
+			labels: map[string]string{
+				"foo": "blah",
+			},
+			fits: false,
+			test: "selector value does not match",
+		},
+		{
+			pod: &v1.Pod{
+				Spec: v1.PodSpec{
+					NodeSelector: map[string]string{
+						"foo": "bar",
+					},
+				},
+			},
+			fits: false,
+			test: "node has no labels",
+		},
+	}
+	expectedFailureReasons := []algorithm.PredicateFailureReason{ErrNodeSelectorNotMatch}
+
+	for _, test := range tests {
+		node := v1.Node{ObjectMeta: metav1.ObjectMeta{Labels: test.labels}}
+		nodeInfo := schedulercache.NewNodeInfo()
+		nodeInfo.SetNode(&node)
+
+		fits, reasons, err := PodMatchNodeSelector(test.pod, PredicateMetadata(test.pod, nil), nodeInfo)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.test, err)
+		}
+		if !fits && !reflect.DeepEqual(reasons, expectedFailureReasons) {
+			t.Errorf("%s: unexpected failure reasons: %v, want: %v", test.test, reasons, expectedFailureReasons)
+		}
+		if fits != test.fits {
+			t.Errorf("%s: expected: %v got %v", test.test, test.fits, fits)
+		}
+	}
+}
+
+func TestPodMatchNodeAffinity(t *testing.T) {
+	requiredAffinityPod := func(terms ...v1.NodeSelectorTerm) *v1.Pod {
+		return &v1.Pod{
+			Spec: v1.PodSpec{
+				Affinity: &v1.Affinity{
+					NodeAffinity: &v1.NodeAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+							NodeSelectorTerms: terms,
+						},
+					},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		pod    *v1.Pod
+		labels map[string]string
+		fits   bool
+		test   string
+	}{
+		{
+			pod:    &v1.Pod{},
+			labels: map[string]string{"foo": "bar"},
+			fits:   true,
+			test:   "no required affinity fits any node",
+		},
+		{
+			pod: requiredAffinityPod(v1.NodeSelectorTerm{
+				MatchExpressions: []v1.NodeSelectorRequirement{
+					{Key: "foo", Operator: v1.NodeSelectorOpIn, Values: []string{"bar", "value2"}},
+				},
+			}),
+			labels: map[string]string{"foo": "bar"},
+			fits:   true,
+			test:   "In operator matches",
+		},
+		{
+			pod: requiredAffinityPod(v1.NodeSelectorTerm{
+				MatchExpressions: []v1.NodeSelectorRequirement{
+					{Key: "foo", Operator: v1.NodeSelectorOpIn, Values: []string{"value2"}},
+				},
+			}),
+			labels: map[string]string{"foo": "bar"},
+			fits:   false,
+			test:   "In operator does not match",
+		},
+		{
+			pod: requiredAffinityPod(v1.NodeSelectorTerm{
+				MatchExpressions: []v1.NodeSelectorRequirement{
+					{Key: "foo", Operator: v1.NodeSelectorOpNotIn, Values: []string{"bar"}},
+				},
+			}),
+			labels: map[string]string{"foo": "bar"},
+			fits:   false,
+			test:   "NotIn operator rejects a matching label",
+		},
+		{
+			pod: requiredAffinityPod(v1.NodeSelectorTerm{
+				MatchExpressions: []v1.NodeSelectorRequirement{
+					{Key: "foo", Operator: v1.NodeSelectorOpExists},
+				},
+			}),
+			labels: map[string]string{"foo": "anything"},
+			fits:   true,
+			test:   "Exists operator matches regardless of value",
+		},
+		{
+			pod: requiredAffinityPod(v1.NodeSelectorTerm{
+				MatchExpressions: []v1.NodeSelectorRequirement{
+					{Key: "foo", Operator: v1.NodeSelectorOpDoesNotExist},
+				},
+			}),
+			labels: map[string]string{"foo": "bar"},
+			fits:   false,
+			test:   "DoesNotExist operator rejects a present label",
+		},
+		{
+			pod: requiredAffinityPod(v1.NodeSelectorTerm{
+				MatchExpressions: []v1.NodeSelectorRequirement{
+					{Key: "foo", Operator: v1.NodeSelectorOpExists},
+					{Key: "region", Operator: v1.NodeSelectorOpIn, Values: []string{"east"}},
+				},
+			}),
+			labels: map[string]string{"foo": "bar", "region": "west"},
+			fits:   false,
+			test:   "expressions within a term are ANDed",
+		},
+		{
+			pod: requiredAffinityPod(
+				v1.NodeSelectorTerm{
+					MatchExpressions: []v1.NodeSelectorRequirement{
+						{Key: "foo", Operator: v1.NodeSelectorOpIn, Values: []string{"value2"}},
+					},
+				},
+				v1.NodeSelectorTerm{
+					MatchExpressions: []v1.NodeSelectorRequirement{
+						{Key: "region", Operator: v1.NodeSelectorOpIn, Values: []string{"west"}},
+					},
+				},
+			),
+			labels: map[string]string{"foo": "bar", "region": "west"},
+			fits:   true,
+			test:   "terms are ORed: a later term may match when the first does not",
+		},
+	}
+	expectedFailureReasons := []algorithm.PredicateFailureReason{ErrNodeAffinityNotMatch}
+
+	for _, test := range tests {
+		node := v1.Node{ObjectMeta: metav1.ObjectMeta{Labels: test.labels}}
+		nodeInfo := schedulercache.NewNodeInfo()
+		nodeInfo.SetNode(&node)
+
+		fits, reasons, err := PodMatchNodeAffinity(test.pod, PredicateMetadata(test.pod, nil), nodeInfo)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.test, err)
+		}
+		if !fits && !reflect.DeepEqual(reasons, expectedFailureReasons) {
+			t.Errorf("%s: unexpected failure reasons: %v, want: %v", test.test, reasons, expectedFailureReasons)
+		}
+		if fits != test.fits {
+			t.Errorf("%s: expected: %v got %v", test.test, test.fits, fits)
+		}
+	}
+}
+
+func TestNodeLocalPodAntiAffinityMatches(t *testing.T) {
+	antiAffinityPod := func(matchLabels map[string]string) *v1.Pod {
+		return &v1.Pod{
+			Spec: v1.PodSpec{
+				Affinity: &v1.Affinity{
+					PodAntiAffinity: &v1.PodAntiAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: []v1.PodAffinityTerm{
+							{
+								LabelSelector: &metav1.LabelSelector{MatchLabels: matchLabels},
+								TopologyKey:   "kubernetes.io/hostname",
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	tests := []struct {
+		pod          *v1.Pod
+		existingPods []*v1.Pod
+		nodeLabels   map[string]string
+		fits         bool
+		test         string
+	}{
+		{
+			pod:          &v1.Pod{},
+			existingPods: []*v1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "existing", Labels: map[string]string{"app": "db"}}}},
+			nodeLabels:   map[string]string{"kubernetes.io/hostname": "machine1"},
+			fits:         true,
+			test:         "no anti-affinity always fits",
+		},
+		{
+			pod:          antiAffinityPod(map[string]string{"app": "db"}),
+			existingPods: []*v1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "existing", Labels: map[string]string{"app": "db"}}}},
+			nodeLabels:   map[string]string{"kubernetes.io/hostname": "machine1"},
+			fits:         false,
+			test:         "existing pod matching the selector within the hostname topology rejects the node",
+		},
+		{
+			pod:          antiAffinityPod(map[string]string{"app": "db"}),
+			existingPods: []*v1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "existing", Labels: map[string]string{"app": "web"}}}},
+			nodeLabels:   map[string]string{"kubernetes.io/hostname": "machine1"},
+			fits:         true,
+			test:         "existing pod not matching the selector fits",
+		},
+		{
+			pod:          antiAffinityPod(map[string]string{"app": "db"}),
+			existingPods: []*v1.Pod{{ObjectMeta: metav1.ObjectMeta{Name: "existing", Labels: map[string]string{"app": "db"}}}},
+			nodeLabels:   map[string]string{},
+			fits:         true,
+			test:         "node without the topology key is not rejected",
+		},
+	}
+
+	for _, test := range tests {
+		node := v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "machine1", Labels: test.nodeLabels}}
+		nodeInfo := schedulercache.NewNodeInfo(test.existingPods...)
+		nodeInfo.SetNode(&node)
+
+		fits, reasons, err := NodeLocalPodAntiAffinityMatches(test.pod, PredicateMetadata(test.pod, nil), nodeInfo)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.test, err)
+		}
+		if fits != test.fits {
+			t.Errorf("%s: expected: %v got %v", test.test, test.fits, fits)
+		}
+		if !fits {
+			if len(reasons) != 1 || !strings.Contains(reasons[0].GetReason(), "kubernetes.io/hostname") {
+				t.Errorf("%s: expected the reason to name the conflicting topology, got %v", test.test, reasons)
+			}
+		}
+	}
+}
+
 func TestNodeLabelPresence(t *testing.T) {
 	label := map[string]string{"foo": "bar", "bar": "foo"}
 	tests := []struct {
@@ -3234,8 +3564,6 @@ func TestPodToleratesTaints(t *testing.T) {
 				"but the effect of taint on node is PreferNochedule. Pod can be scheduled onto the node",
 		},
 	}
-	expectedFailureReasons := []algorithm.PredicateFailureReason{ErrTaintsTolerationsNotMatch}
-
 	for _, test := range podTolerateTaintsTests {
 		nodeInfo := schedulercache.NewNodeInfo()
 		nodeInfo.SetNode(&test.node)
@@ -3243,8 +3571,12 @@ func TestPodToleratesTaints(t *testing.T) {
 		if err != nil {
 			t.Errorf("%s, unexpected error: %v", test.test, err)
 		}
-		if !fits && !reflect.DeepEqual(reasons, expectedFailureReasons) {
-			t.Errorf("%s, unexpected failure reason: %v, want: %v", test.test, reasons, expectedFailureReasons)
+		if !fits {
+			if len(reasons) != 2 || !reflect.DeepEqual(reasons[0], ErrTaintsTolerationsNotMatch) {
+				t.Errorf("%s, unexpected failure reasons: %v, want: %v first", test.test, reasons, ErrTaintsTolerationsNotMatch)
+			} else if !strings.Contains(reasons[1].GetReason(), "untolerated taint") {
+				t.Errorf("%s, expected the failure reason to name the untolerated taint, got: %v", test.test, reasons[1].GetReason())
+			}
 		}
 		if fits != test.fits {
 			t.Errorf("%s, expected: %v got %v", test.test, test.fits, fits)
@@ -112,9 +112,13 @@ func (p *podAffinityPriorityMap) processTerms(terms []v1.WeightedPodAffinityTerm
 	}
 }
 
-// compute a sum by iterating through the elements of weightedPodAffinityTerm and adding
-// "weight" to the sum if the corresponding PodAffinityTerm is satisfied for
-// that node; the node(s) with the highest sum are the most preferred.
+// CalculateInterPodAffinityPriority computes a sum by iterating through the
+// elements of weightedPodAffinityTerm and adding "weight" to the sum if the
+// corresponding PodAffinityTerm is satisfied for that node, and subtracting
+// the weight of satisfied preferred anti-affinity terms; the node(s) with the
+// highest sum are the most preferred. The per-node sums are normalized to the
+// 0-10 priority range, and pods expressing no affinity preferences score all
+// nodes equally.
 // Symmetry need to be considered for preferredDuringSchedulingIgnoredDuringExecution from podAffinity & podAntiAffinity,
 // symmetry need to be considered for hard requirements from podAffinity
 func (ipa *InterPodAffinity) CalculateInterPodAffinityPriority(pod *v1.Pod, nodeNameToInfo map[string]*schedulercache.NodeInfo, nodes []*v1.Node) (schedulerapi.HostPriorityList, error) {
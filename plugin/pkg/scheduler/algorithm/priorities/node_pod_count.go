@@ -0,0 +1,53 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package priorities
+
+import (
+	"k8s.io/kubernetes/pkg/api/v1"
+	schedulerapi "k8s.io/kubernetes/plugin/pkg/scheduler/api"
+	"k8s.io/kubernetes/plugin/pkg/scheduler/schedulercache"
+)
+
+// NodePodCountPriority scores nodes inversely to their total pod count: the
+// least-loaded node scores 10 and the most-loaded node scores 0.  It is a
+// cheap spreading signal for clusters where no richer balancing priority is
+// configured.  When every node carries the same number of pods all nodes
+// score equally.
+func NodePodCountPriority(pod *v1.Pod, nodeNameToInfo map[string]*schedulercache.NodeInfo, nodes []*v1.Node) (schedulerapi.HostPriorityList, error) {
+	minCount := -1
+	maxCount := 0
+	for _, node := range nodes {
+		count := len(nodeNameToInfo[node.Name].Pods())
+		if minCount < 0 || count < minCount {
+			minCount = count
+		}
+		if count > maxCount {
+			maxCount = count
+		}
+	}
+
+	result := make(schedulerapi.HostPriorityList, 0, len(nodes))
+	for _, node := range nodes {
+		score := int(maxPriority)
+		if maxCount > minCount {
+			count := len(nodeNameToInfo[node.Name].Pods())
+			score = int(maxPriority * float32(maxCount-count) / float32(maxCount-minCount))
+		}
+		result = append(result, schedulerapi.HostPriority{Host: node.Name, Score: score})
+	}
+	return result, nil
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package priorities
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubernetes/pkg/api/v1"
+	schedulerapi "k8s.io/kubernetes/plugin/pkg/scheduler/api"
+	"k8s.io/kubernetes/plugin/pkg/scheduler/schedulercache"
+)
+
+func TestNodePodCountPriority(t *testing.T) {
+	podOnNode := func(node string) *v1.Pod {
+		return &v1.Pod{Spec: v1.PodSpec{NodeName: node}}
+	}
+	nodes := []*v1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "machine1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "machine2"}},
+	}
+
+	tests := []struct {
+		pods         []*v1.Pod
+		expectedList schedulerapi.HostPriorityList
+		test         string
+	}{
+		{
+			pods: []*v1.Pod{podOnNode("machine1"), podOnNode("machine1"), podOnNode("machine2")},
+			expectedList: []schedulerapi.HostPriority{
+				{Host: "machine1", Score: 0},
+				{Host: "machine2", Score: 10},
+			},
+			test: "least-loaded node scores highest",
+		},
+		{
+			pods: []*v1.Pod{podOnNode("machine1"), podOnNode("machine2")},
+			expectedList: []schedulerapi.HostPriority{
+				{Host: "machine1", Score: 10},
+				{Host: "machine2", Score: 10},
+			},
+			test: "equal pod counts score equally",
+		},
+		{
+			pods: nil,
+			expectedList: []schedulerapi.HostPriority{
+				{Host: "machine1", Score: 10},
+				{Host: "machine2", Score: 10},
+			},
+			test: "empty nodes score equally",
+		},
+	}
+
+	for _, test := range tests {
+		nodeNameToInfo := schedulercache.CreateNodeNameToInfoMap(test.pods, nodes)
+		list, err := NodePodCountPriority(&v1.Pod{}, nodeNameToInfo, nodes)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.test, err)
+		}
+		sort.Sort(test.expectedList)
+		sort.Sort(list)
+		if !reflect.DeepEqual(test.expectedList, list) {
+			t.Errorf("%s: expected %#v, got %#v", test.test, test.expectedList, list)
+		}
+	}
+}
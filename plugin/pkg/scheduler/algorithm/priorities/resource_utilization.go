@@ -0,0 +1,33 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package priorities
+
+import (
+	"k8s.io/kubernetes/plugin/pkg/scheduler/algorithm"
+)
+
+// NewResourceUtilizationPriority selects between the two resource utilization
+// scorers at construction time: binPack favors nodes with the most requested
+// CPU and memory (MostRequestedPriority), while the default favors the least
+// requested nodes to spread load (LeastRequestedPriority). Both score on the
+// usual 0-10 scale against node allocatable capacity.
+func NewResourceUtilizationPriority(binPack bool) algorithm.PriorityMapFunction {
+	if binPack {
+		return MostRequestedPriorityMap
+	}
+	return LeastRequestedPriorityMap
+}
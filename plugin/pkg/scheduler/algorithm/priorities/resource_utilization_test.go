@@ -0,0 +1,72 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package priorities
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/api/v1"
+	"k8s.io/kubernetes/plugin/pkg/scheduler/schedulercache"
+)
+
+// TestResourceUtilizationVariants runs both variants over a loaded and an
+// idle node: the spreading variant must prefer the idle node and the
+// bin-packing variant the loaded one.
+func TestResourceUtilizationVariants(t *testing.T) {
+	existingPod := &v1.Pod{
+		Spec: v1.PodSpec{
+			NodeName: "loaded",
+			Containers: []v1.Container{
+				{
+					Resources: v1.ResourceRequirements{
+						Requests: v1.ResourceList{
+							"cpu":    resource.MustParse("3000m"),
+							"memory": resource.MustParse("10000"),
+						},
+					},
+				},
+			},
+		},
+	}
+	pod := &v1.Pod{Spec: v1.PodSpec{Containers: []v1.Container{}}}
+
+	nodeInfos := map[string]*schedulercache.NodeInfo{
+		"loaded": schedulercache.NewNodeInfo(existingPod),
+		"idle":   schedulercache.NewNodeInfo(),
+	}
+	nodeInfos["loaded"].SetNode(makeNode("loaded", 4000, 20000))
+	nodeInfos["idle"].SetNode(makeNode("idle", 4000, 20000))
+
+	score := func(binPack bool, nodeName string) int {
+		hostPriority, err := NewResourceUtilizationPriority(binPack)(pod, nil, nodeInfos[nodeName])
+		if err != nil {
+			t.Fatalf("unexpected error scoring %s: %v", nodeName, err)
+		}
+		if hostPriority.Score < 0 || hostPriority.Score > 10 {
+			t.Errorf("score for %s out of the 0-10 range: %d", nodeName, hostPriority.Score)
+		}
+		return hostPriority.Score
+	}
+
+	if loaded, idle := score(false, "loaded"), score(false, "idle"); loaded >= idle {
+		t.Errorf("spreading must prefer the idle node: loaded=%d idle=%d", loaded, idle)
+	}
+	if loaded, idle := score(true, "loaded"), score(true, "idle"); loaded <= idle {
+		t.Errorf("bin-packing must prefer the loaded node: loaded=%d idle=%d", loaded, idle)
+	}
+}
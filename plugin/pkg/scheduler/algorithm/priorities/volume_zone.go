@@ -0,0 +1,105 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package priorities
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kubernetes/pkg/api/v1"
+	"k8s.io/kubernetes/plugin/pkg/scheduler/algorithm"
+	"k8s.io/kubernetes/plugin/pkg/scheduler/algorithm/predicates"
+	schedulerapi "k8s.io/kubernetes/plugin/pkg/scheduler/api"
+	"k8s.io/kubernetes/plugin/pkg/scheduler/schedulercache"
+)
+
+type VolumeZonePrioritizer struct {
+	pvInfo  predicates.PersistentVolumeInfo
+	pvcInfo predicates.PersistentVolumeClaimInfo
+}
+
+// NewVolumeZonePriority returns a priority function that scores nodes in the
+// zone of the pod's bound PersistentVolumes at 10 and all other nodes at 0.
+// When the pod references multiple zonal volumes the allowed zones are
+// intersected. Pods without zonal volumes score all nodes equally.
+func NewVolumeZonePriority(pvInfo predicates.PersistentVolumeInfo, pvcInfo predicates.PersistentVolumeClaimInfo) algorithm.PriorityFunction {
+	p := &VolumeZonePrioritizer{
+		pvInfo:  pvInfo,
+		pvcInfo: pvcInfo,
+	}
+	return p.CalculateVolumeZonePriority
+}
+
+// CalculateVolumeZonePriority scores the given nodes by whether they are in a
+// zone that can serve all of the pod's zonal PersistentVolumes.
+func (p *VolumeZonePrioritizer) CalculateVolumeZonePriority(pod *v1.Pod, nodeNameToInfo map[string]*schedulercache.NodeInfo, nodes []*v1.Node) (schedulerapi.HostPriorityList, error) {
+	zones, hasZonalVolume, err := p.volumeZones(pod)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(schedulerapi.HostPriorityList, 0, len(nodes))
+	for _, node := range nodes {
+		score := 10
+		if hasZonalVolume {
+			nodeZone, ok := node.Labels[metav1.LabelZoneFailureDomain]
+			if !ok || !zones.Has(nodeZone) {
+				score = 0
+			}
+		}
+		result = append(result, schedulerapi.HostPriority{Host: node.Name, Score: score})
+	}
+	return result, nil
+}
+
+// volumeZones computes the set of zones that can serve every zonal
+// PersistentVolume the pod references, by intersecting the zone labels of the
+// bound volumes. The second return value is false if the pod has no zonal
+// volumes.
+func (p *VolumeZonePrioritizer) volumeZones(pod *v1.Pod) (sets.String, bool, error) {
+	var zones sets.String
+	for i := range pod.Spec.Volumes {
+		volume := &pod.Spec.Volumes[i]
+		if volume.PersistentVolumeClaim == nil {
+			continue
+		}
+		pvc, err := p.pvcInfo.GetPersistentVolumeClaimInfo(pod.Namespace, volume.PersistentVolumeClaim.ClaimName)
+		if err != nil {
+			return nil, false, err
+		}
+		if pvc == nil || pvc.Spec.VolumeName == "" {
+			continue
+		}
+		pv, err := p.pvInfo.GetPersistentVolumeInfo(pvc.Spec.VolumeName)
+		if err != nil {
+			return nil, false, err
+		}
+		if pv == nil {
+			continue
+		}
+		zone, ok := pv.Labels[metav1.LabelZoneFailureDomain]
+		if !ok {
+			continue
+		}
+		volumeZones := sets.NewString(zone)
+		if zones == nil {
+			zones = volumeZones
+		} else {
+			zones = zones.Intersection(volumeZones)
+		}
+	}
+	return zones, zones != nil, nil
+}
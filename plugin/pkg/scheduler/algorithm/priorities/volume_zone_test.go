@@ -0,0 +1,131 @@
+/*
+Copyright 2017 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package priorities
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubernetes/pkg/api/v1"
+	schedulerapi "k8s.io/kubernetes/plugin/pkg/scheduler/api"
+	"k8s.io/kubernetes/plugin/pkg/scheduler/schedulercache"
+)
+
+type fakePersistentVolumeInfo []v1.PersistentVolume
+
+func (pvs fakePersistentVolumeInfo) GetPersistentVolumeInfo(pvID string) (*v1.PersistentVolume, error) {
+	for _, pv := range pvs {
+		if pv.Name == pvID {
+			return &pv, nil
+		}
+	}
+	return nil, fmt.Errorf("Unable to find persistent volume: %s", pvID)
+}
+
+type fakePersistentVolumeClaimInfo []v1.PersistentVolumeClaim
+
+func (pvcs fakePersistentVolumeClaimInfo) GetPersistentVolumeClaimInfo(namespace string, pvcID string) (*v1.PersistentVolumeClaim, error) {
+	for _, pvc := range pvcs {
+		if pvc.Name == pvcID && pvc.Namespace == namespace {
+			return &pvc, nil
+		}
+	}
+	return nil, fmt.Errorf("Unable to find persistent volume claim: %s/%s", namespace, pvcID)
+}
+
+func TestVolumeZonePriority(t *testing.T) {
+	pvInfo := fakePersistentVolumeInfo{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "pv-a",
+				Labels: map[string]string{metav1.LabelZoneFailureDomain: "zone-a"},
+			},
+		},
+	}
+	pvcInfo := fakePersistentVolumeClaimInfo{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "claim-a", Namespace: "default"},
+			Spec:       v1.PersistentVolumeClaimSpec{VolumeName: "pv-a"},
+		},
+	}
+
+	nodes := []*v1.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "machine1",
+				Labels: map[string]string{metav1.LabelZoneFailureDomain: "zone-a"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   "machine2",
+				Labels: map[string]string{metav1.LabelZoneFailureDomain: "zone-b"},
+			},
+		},
+	}
+
+	podWithClaim := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default"},
+		Spec: v1.PodSpec{
+			Volumes: []v1.Volume{
+				{
+					Name: "data",
+					VolumeSource: v1.VolumeSource{
+						PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "claim-a"},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		pod          *v1.Pod
+		expectedList schedulerapi.HostPriorityList
+		test         string
+	}{
+		{
+			pod: podWithClaim,
+			expectedList: []schedulerapi.HostPriority{
+				{Host: "machine1", Score: 10},
+				{Host: "machine2", Score: 0},
+			},
+			test: "pod with single-zone PVC prefers the volume's zone",
+		},
+		{
+			pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "default"}},
+			expectedList: []schedulerapi.HostPriority{
+				{Host: "machine1", Score: 10},
+				{Host: "machine2", Score: 10},
+			},
+			test: "pod without zonal volumes scores all nodes equally",
+		},
+	}
+
+	for _, test := range tests {
+		prioritize := NewVolumeZonePriority(pvInfo, pvcInfo)
+		list, err := prioritize(test.pod, map[string]*schedulercache.NodeInfo{}, nodes)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.test, err)
+			continue
+		}
+		if !reflect.DeepEqual(test.expectedList, list) {
+			t.Errorf("%s: expected %#v, got %#v", test.test, test.expectedList, list)
+		}
+	}
+}
@@ -30,10 +30,27 @@ type SchedulerExtender interface {
 	// the list of failed nodes and failure reasons.
 	Filter(pod *v1.Pod, nodes []*v1.Node) (filteredNodes []*v1.Node, failedNodesMap schedulerapi.FailedNodesMap, err error)
 
+	// IsFilterLenient returns true if a Filter error from this extender should be
+	// tolerated by passing the candidate nodes through unchanged, instead of
+	// aborting scheduling for the pod.
+	IsFilterLenient() bool
+
 	// Prioritize based on extender-implemented priority functions. The returned scores & weight
 	// are used to compute the weighted score for an extender. The weighted scores are added to
 	// the scores computed  by Kubernetes scheduler. The total scores are used to do the host selection.
 	Prioritize(pod *v1.Pod, nodes []*v1.Node) (hostPriorities *schedulerapi.HostPriorityList, weight int, err error)
+
+	// IsHardError returns true if a Prioritize error from this extender should abort
+	// scheduling for the pod instead of being ignored.
+	IsHardError() bool
+
+	// IsBinder returns true if this extender performs the Bind for pods it
+	// admits, in place of the scheduler's default binding.
+	IsBinder() bool
+
+	// Bind delegates the binding of the pod to its selected node to the
+	// extender. Only called when IsBinder returns true.
+	Bind(binding *v1.Binding) error
 }
 
 // ScheduleAlgorithm is an interface implemented by things that know how to schedule pods
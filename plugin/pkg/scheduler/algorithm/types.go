@@ -41,11 +41,31 @@ type PriorityReduceFunction func(pod *v1.Pod, meta interface{}, nodeNameToInfo m
 // MetdataProducer is a function that computes metadata for a given pod.
 type MetadataProducer func(pod *v1.Pod, nodeNameToInfo map[string]*schedulercache.NodeInfo) interface{}
 
+// Tracer receives a record for every node considered while scheduling a
+// single pod, so callers can reconstruct why each node was or wasn't chosen.
+// Infeasible nodes are reported with the reasons their predicates failed;
+// feasible nodes are reported with their final score.
+type Tracer interface {
+	RecordNode(nodeName string, feasible bool, reasons []string, score int)
+}
+
+// StickyNodeHint is a function that returns the name of the node a pod
+// prefers to be placed back on (e.g. the node it last ran on, recorded in an
+// annotation), or the empty string if the pod has no such preference.
+type StickyNodeHint func(pod *v1.Pod) string
+
+// QoSWeights is a function that returns per-priority weight overrides for a
+// specific pod, keyed by priority name, so e.g. guaranteed pods can weight
+// bin-packing differently than best-effort pods. Priorities not present in
+// the returned map keep their configured weight.
+type QoSWeights func(pod *v1.Pod) map[string]int
+
 // DEPRECATED
 // Use Map-Reduce pattern for priority functions.
 type PriorityFunction func(pod *v1.Pod, nodeNameToInfo map[string]*schedulercache.NodeInfo, nodes []*v1.Node) (schedulerapi.HostPriorityList, error)
 
 type PriorityConfig struct {
+	Name   string
 	Map    PriorityMapFunction
 	Reduce PriorityReduceFunction
 	// TODO: Remove it after migrating all functions to
@@ -79,6 +79,18 @@ func init() {
 	factory.RegisterFitPredicate("HostName", predicates.PodFitsHost)
 	// Fit is determined by node selector query.
 	factory.RegisterFitPredicate("MatchNodeSelector", predicates.PodSelectorMatches)
+	// Fit is determined by the pod's node selector alone, ignoring node affinity.
+	// Node selectors are already enforced by default through GeneralPredicates;
+	// this predicate is registered for configurations that want label matching only.
+	factory.RegisterFitPredicate("PodMatchNodeSelector", predicates.PodMatchNodeSelector)
+	// Fit is determined by the pod's required node affinity alone. Like node
+	// selectors, required affinity is already enforced by default through
+	// GeneralPredicates' MatchNodeSelector.
+	factory.RegisterFitPredicate("MatchNodeAffinity", predicates.PodMatchNodeAffinity)
+	// Fit is determined by the pod's anti-affinity terms against the pods already
+	// assigned to the node, limited to node-scoped topologies. The full inter-pod
+	// affinity predicate is part of defaultPredicates below.
+	factory.RegisterFitPredicate("NodeLocalPodAntiAffinity", predicates.NodeLocalPodAntiAffinityMatches)
 
 	// Use equivalence class to speed up predicates & priorities
 	factory.RegisterGetEquivalencePodFunction(GetEquivalencePod)
@@ -128,6 +128,17 @@ type ExtenderConfig struct {
 	// HTTPTimeout specifies the timeout duration for a call to the extender. Filter timeout fails the scheduling of the pod. Prioritize
 	// timeout is ignored, k8s/other extenders priorities are used to select the node.
 	HTTPTimeout time.Duration `json:"httpTimeout,omitempty"`
+	// HardError specifies whether a prioritize error from this extender fails the
+	// scheduling of the pod. By default prioritize errors are ignored and
+	// k8s/other extenders priorities are used to select the node.
+	HardError bool `json:"hardError,omitempty"`
+	// FilterLenient specifies whether a filter error from this extender is
+	// tolerated by passing the candidate nodes through unchanged. By default
+	// filter errors fail the scheduling of the pod.
+	FilterLenient bool `json:"filterLenient,omitempty"`
+	// Verb for the bind call, empty if not supported. This verb is appended to the URLPrefix when issuing the bind call to extender.
+	// If this method is implemented by the extender, it is the extender's responsibility to bind the pod to apiserver.
+	BindVerb string `json:"bindVerb,omitempty"`
 }
 
 // ExtenderArgs represents the arguments needed by the extender to filter/prioritize
@@ -152,6 +163,22 @@ type ExtenderFilterResult struct {
 	Error string `json:"error,omitempty"`
 }
 
+// ExtenderBindingArgs represents the arguments to an extender for binding a pod to a node.
+type ExtenderBindingArgs struct {
+	// PodName is the name of the pod being bound
+	PodName string `json:"podName"`
+	// PodNamespace is the namespace of the pod being bound
+	PodNamespace string `json:"podNamespace"`
+	// Node selected by the scheduler
+	Node string `json:"node"`
+}
+
+// ExtenderBindingResult represents the result of binding of a pod to a node from an extender.
+type ExtenderBindingResult struct {
+	// Error message indicating failure
+	Error string `json:"error,omitempty"`
+}
+
 // HostPriority represents the priority of scheduling to a particular host, higher priority is better.
 type HostPriority struct {
 	// Name of the host
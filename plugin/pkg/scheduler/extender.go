@@ -40,8 +40,11 @@ type HTTPExtender struct {
 	extenderURL    string
 	filterVerb     string
 	prioritizeVerb string
+	bindVerb       string
 	weight         int
 	apiVersion     string
+	hardError      bool
+	filterLenient  bool
 	client         *http.Client
 }
 
@@ -86,11 +89,26 @@ func NewHTTPExtender(config *schedulerapi.ExtenderConfig, apiVersion string) (al
 		apiVersion:     apiVersion,
 		filterVerb:     config.FilterVerb,
 		prioritizeVerb: config.PrioritizeVerb,
+		bindVerb:       config.BindVerb,
 		weight:         config.Weight,
+		hardError:      config.HardError,
+		filterLenient:  config.FilterLenient,
 		client:         client,
 	}, nil
 }
 
+// IsHardError returns true if a prioritize error from this extender fails the
+// scheduling of the pod.
+func (h *HTTPExtender) IsHardError() bool {
+	return h.hardError
+}
+
+// IsFilterLenient returns true if a filter error from this extender leaves the
+// candidate nodes unchanged instead of failing the scheduling of the pod.
+func (h *HTTPExtender) IsFilterLenient() bool {
+	return h.filterLenient
+}
+
 // Filter based on extender implemented predicate functions. The filtered list is
 // expected to be a subset of the supplied list. failedNodesMap optionally contains
 // the list of failed nodes and failure reasons.
@@ -153,6 +171,32 @@ func (h *HTTPExtender) Prioritize(pod *v1.Pod, nodes []*v1.Node) (*schedulerapi.
 	return &result, h.weight, nil
 }
 
+// IsBinder returns true when the extender was configured with a bind verb and
+// therefore takes over binding for the pods it schedules.
+func (h *HTTPExtender) IsBinder() bool {
+	return h.bindVerb != ""
+}
+
+// Bind delegates the binding of a pod to a node to the extender.
+func (h *HTTPExtender) Bind(binding *v1.Binding) error {
+	var result schedulerapi.ExtenderBindingResult
+	if !h.IsBinder() {
+		return fmt.Errorf("unexpected empty bindVerb in extender")
+	}
+	args := &schedulerapi.ExtenderBindingArgs{
+		PodName:      binding.Name,
+		PodNamespace: binding.Namespace,
+		Node:         binding.Target.Name,
+	}
+	if err := h.send(h.bindVerb, args, &result); err != nil {
+		return err
+	}
+	if result.Error != "" {
+		return fmt.Errorf(result.Error)
+	}
+	return nil
+}
+
 // Helper function to send messages to the extender
 func (h *HTTPExtender) send(action string, args interface{}, result interface{}) error {
 	out, err := json.Marshal(args)
@@ -185,3 +229,97 @@ func (h *HTTPExtender) send(action string, args interface{}, result interface{})
 	}
 	return nil
 }
+
+// timeoutExtender wraps a SchedulerExtender and bounds the duration of its
+// Filter and Prioritize calls. On timeout an ignorable extender is skipped
+// (all nodes pass filtering, no priorities are contributed), while a fatal
+// one fails the scheduling of the pod.
+type timeoutExtender struct {
+	delegate  algorithm.SchedulerExtender
+	timeout   time.Duration
+	ignorable bool
+}
+
+// NewTimeoutExtender returns an extender that delegates to the given extender
+// but gives up on calls that run longer than timeout. If ignorable is true a
+// timed out call is treated as if the extender were absent, otherwise it
+// fails scheduling.
+func NewTimeoutExtender(delegate algorithm.SchedulerExtender, timeout time.Duration, ignorable bool) algorithm.SchedulerExtender {
+	return &timeoutExtender{delegate: delegate, timeout: timeout, ignorable: ignorable}
+}
+
+type extenderFilterResult struct {
+	nodes          []*v1.Node
+	failedNodesMap schedulerapi.FailedNodesMap
+	err            error
+}
+
+type extenderPrioritizeResult struct {
+	hostPriorities *schedulerapi.HostPriorityList
+	weight         int
+	err            error
+}
+
+func (e *timeoutExtender) Filter(pod *v1.Pod, nodes []*v1.Node) ([]*v1.Node, schedulerapi.FailedNodesMap, error) {
+	ch := make(chan extenderFilterResult, 1)
+	go func() {
+		filtered, failedNodesMap, err := e.delegate.Filter(pod, nodes)
+		ch <- extenderFilterResult{filtered, failedNodesMap, err}
+	}()
+	select {
+	case result := <-ch:
+		return result.nodes, result.failedNodesMap, result.err
+	case <-time.After(e.timeout):
+		if e.ignorable {
+			return nodes, schedulerapi.FailedNodesMap{}, nil
+		}
+		return nil, nil, fmt.Errorf("extender filter call timed out after %v", e.timeout)
+	}
+}
+
+func (e *timeoutExtender) Prioritize(pod *v1.Pod, nodes []*v1.Node) (*schedulerapi.HostPriorityList, int, error) {
+	ch := make(chan extenderPrioritizeResult, 1)
+	go func() {
+		hostPriorities, weight, err := e.delegate.Prioritize(pod, nodes)
+		ch <- extenderPrioritizeResult{hostPriorities, weight, err}
+	}()
+	select {
+	case result := <-ch:
+		return result.hostPriorities, result.weight, result.err
+	case <-time.After(e.timeout):
+		if e.ignorable {
+			return &schedulerapi.HostPriorityList{}, 0, nil
+		}
+		return nil, 0, fmt.Errorf("extender prioritize call timed out after %v", e.timeout)
+	}
+}
+
+// IsHardError returns true for a fatal extender so that a prioritize timeout
+// fails the scheduling of the pod.
+func (e *timeoutExtender) IsHardError() bool {
+	if e.ignorable {
+		return e.delegate.IsHardError()
+	}
+	return true
+}
+
+// IsFilterLenient defers to the delegate for an ignorable extender; a fatal
+// one never tolerates filter errors.
+func (e *timeoutExtender) IsFilterLenient() bool {
+	if e.ignorable {
+		return e.delegate.IsFilterLenient()
+	}
+	return false
+}
+
+// IsBinder defers to the delegate: wrapping an extender in a timeout does not
+// change who performs the bind.
+func (e *timeoutExtender) IsBinder() bool {
+	return e.delegate.IsBinder()
+}
+
+// Bind is not bounded by the timeout: an interrupted bind could leave the pod
+// half bound, so the delegate is given however long it needs.
+func (e *timeoutExtender) Bind(binding *v1.Binding) error {
+	return e.delegate.Bind(binding)
+}
@@ -104,9 +104,32 @@ func machine2Prioritizer(_ *v1.Pod, nodeNameToInfo map[string]*schedulercache.No
 }
 
 type FakeExtender struct {
-	predicates   []fitPredicate
-	prioritizers []priorityConfig
-	weight       int
+	predicates    []fitPredicate
+	prioritizers  []priorityConfig
+	weight        int
+	hardError     bool
+	filterLenient bool
+	isBinder      bool
+
+	// gotBind keeps the binding the extender was asked to perform, if any.
+	gotBind *v1.Binding
+}
+
+func (f *FakeExtender) IsHardError() bool {
+	return f.hardError
+}
+
+func (f *FakeExtender) IsFilterLenient() bool {
+	return f.filterLenient
+}
+
+func (f *FakeExtender) IsBinder() bool {
+	return f.isBinder
+}
+
+func (f *FakeExtender) Bind(binding *v1.Binding) error {
+	f.gotBind = binding
+	return nil
 }
 
 func (f *FakeExtender) Filter(pod *v1.Pod, nodes []*v1.Node) ([]*v1.Node, schedulerapi.FailedNodesMap, error) {
@@ -275,6 +298,56 @@ func TestGenericSchedulerWithExtenders(t *testing.T) {
 			expectedHost: "machine2", // machine2 has higher score
 			name:         "test 7",
 		},
+		{
+			// Prioritize errors from a hardError extender fail scheduling,
+			// unlike test 5 where they are ignored.
+			predicates:   map[string]algorithm.FitPredicate{"true": truePredicate},
+			prioritizers: []algorithm.PriorityConfig{{Map: EqualPriorityMap, Weight: 1}},
+			extenders: []FakeExtender{
+				{
+					predicates:   []fitPredicate{truePredicateExtender},
+					prioritizers: []priorityConfig{{errorPrioritizerExtender, 10}},
+					weight:       1,
+					hardError:    true,
+				},
+			},
+			nodes:      []string{"machine1"},
+			expectsErr: true,
+			name:       "test 8",
+		},
+		{
+			// Filter errors fail scheduling by default, as in test 1; a
+			// lenient extender instead passes the candidate nodes through.
+			predicates:   map[string]algorithm.FitPredicate{"true": truePredicate},
+			prioritizers: []algorithm.PriorityConfig{{Function: machine2Prioritizer, Weight: 20}},
+			extenders: []FakeExtender{
+				{
+					predicates:    []fitPredicate{errorPredicateExtender},
+					filterLenient: true,
+				},
+			},
+			nodes:        []string{"machine1", "machine2"},
+			expectedHost: "machine2",
+			name:         "test 9",
+		},
+		{
+			// The lenient extender's failure must not shadow a later
+			// authoritative extender.
+			predicates:   map[string]algorithm.FitPredicate{"true": truePredicate},
+			prioritizers: []algorithm.PriorityConfig{{Map: EqualPriorityMap, Weight: 1}},
+			extenders: []FakeExtender{
+				{
+					predicates:    []fitPredicate{errorPredicateExtender},
+					filterLenient: true,
+				},
+				{
+					predicates: []fitPredicate{machine1PredicateExtender},
+				},
+			},
+			nodes:        []string{"machine1", "machine2"},
+			expectedHost: "machine1",
+			name:         "test 10",
+		},
 	}
 
 	for _, test := range tests {
@@ -305,3 +378,75 @@ func TestGenericSchedulerWithExtenders(t *testing.T) {
 		}
 	}
 }
+
+// sleepingExtender delays every Filter and Prioritize call, for exercising
+// the timeoutExtender wrapper.
+type sleepingExtender struct {
+	FakeExtender
+	delay time.Duration
+}
+
+func (s *sleepingExtender) Filter(pod *v1.Pod, nodes []*v1.Node) ([]*v1.Node, schedulerapi.FailedNodesMap, error) {
+	time.Sleep(s.delay)
+	return s.FakeExtender.Filter(pod, nodes)
+}
+
+func (s *sleepingExtender) Prioritize(pod *v1.Pod, nodes []*v1.Node) (*schedulerapi.HostPriorityList, int, error) {
+	time.Sleep(s.delay)
+	return s.FakeExtender.Prioritize(pod, nodes)
+}
+
+func TestTimeoutExtender(t *testing.T) {
+	nodes := makeNodeList([]string{"machine1", "machine2"})
+	pod := &v1.Pod{}
+	slow := &sleepingExtender{
+		FakeExtender: FakeExtender{
+			predicates:   []fitPredicate{machine1PredicateExtender},
+			prioritizers: []priorityConfig{{machine1PrioritizerExtender, 10}},
+			weight:       1,
+		},
+		delay: 100 * time.Millisecond,
+	}
+
+	// An ignorable extender that times out is treated as absent.
+	ignorable := NewTimeoutExtender(slow, time.Millisecond, true)
+	filtered, _, err := ignorable.Filter(pod, nodes)
+	if err != nil {
+		t.Errorf("unexpected error from ignorable extender: %v", err)
+	}
+	if len(filtered) != len(nodes) {
+		t.Errorf("expected all %d nodes to pass, got %d", len(nodes), len(filtered))
+	}
+	priorities, weight, err := ignorable.Prioritize(pod, nodes)
+	if err != nil {
+		t.Errorf("unexpected error from ignorable extender: %v", err)
+	}
+	if len(*priorities) != 0 || weight != 0 {
+		t.Errorf("expected no priorities from timed out ignorable extender, got %v with weight %d", *priorities, weight)
+	}
+	if ignorable.IsHardError() {
+		t.Errorf("ignorable extender should not report hard errors")
+	}
+
+	// A fatal extender that times out fails the call.
+	fatal := NewTimeoutExtender(slow, time.Millisecond, false)
+	if _, _, err := fatal.Filter(pod, nodes); err == nil {
+		t.Errorf("expected filter timeout error from fatal extender")
+	}
+	if _, _, err := fatal.Prioritize(pod, nodes); err == nil {
+		t.Errorf("expected prioritize timeout error from fatal extender")
+	}
+	if !fatal.IsHardError() {
+		t.Errorf("fatal extender should report hard errors")
+	}
+
+	// A fast delegate is unaffected by the wrapper.
+	fast := NewTimeoutExtender(slow, time.Second, false)
+	filtered, _, err = fast.Filter(pod, nodes)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(filtered) != 1 || filtered[0].Name != "machine1" {
+		t.Errorf("expected delegate filter result, got %v", filtered)
+	}
+}
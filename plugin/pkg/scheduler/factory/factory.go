@@ -407,6 +407,7 @@ func (f *ConfigFactory) CreateFromKeys(predicateKeys, priorityKeys sets.String,
 		NodeLister:          f.nodeLister.NodeCondition(getNodeConditionPredicate()),
 		Algorithm:           algo,
 		Binder:              &binder{f.client},
+		Extenders:           extenders,
 		PodConditionUpdater: &podConditionUpdater{f.client},
 		NextPod: func() *v1.Pod {
 			return f.getNextPod()
@@ -17,7 +17,9 @@ limitations under the License.
 package scheduler
 
 import (
+	"context"
 	"fmt"
+	"runtime"
 	"sort"
 	"strings"
 	"sync"
@@ -26,6 +28,7 @@ import (
 
 	"github.com/golang/glog"
 	"k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	utiltrace "k8s.io/apiserver/pkg/util/trace"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/kubernetes/pkg/api/v1"
@@ -37,34 +40,87 @@ import (
 
 type FailedPredicateMap map[string][]algorithm.PredicateFailureReason
 
+// ReasonSets flattens the structured failure reasons into the legacy
+// node-name-to-reason-string-set form, for callers that only need to know
+// which predicates failed and not why.
+func (f FailedPredicateMap) ReasonSets() map[string]sets.String {
+	result := make(map[string]sets.String, len(f))
+	for nodeName, failureReasons := range f {
+		reasons := sets.NewString()
+		for _, reason := range failureReasons {
+			reasons.Insert(reason.GetReason())
+		}
+		result[nodeName] = reasons
+	}
+	return result
+}
+
 type FitError struct {
 	Pod              *v1.Pod
+	NumAllNodes      int
+	NumFailedNodes   int
 	FailedPredicates FailedPredicateMap
 }
 
 var ErrNoNodesAvailable = fmt.Errorf("no nodes available to schedule pods")
 
-const NoNodeAvailableMsg = "No nodes are available that match all of the following predicates:"
+const NoNodeAvailableMsg = "nodes are available"
+
+// reasonBucket pairs a predicate failure reason with the number of nodes it
+// failed on.
+type reasonBucket struct {
+	reason string
+	count  int
+}
+
+// reasonHistogram sorts buckets by descending count, breaking ties on the
+// reason string so the rendered message is deterministic.
+type reasonHistogram []reasonBucket
+
+func (h reasonHistogram) Len() int      { return len(h) }
+func (h reasonHistogram) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h reasonHistogram) Less(i, j int) bool {
+	if h[i].count == h[j].count {
+		return h[i].reason < h[j].reason
+	}
+	return h[i].count > h[j].count
+}
 
-// Error returns detailed information of why the pod failed to fit on each node
+// Error returns detailed information of why the pod failed to fit on each node,
+// summarized as failure counts per predicate reason ordered by frequency.
 func (f *FitError) Error() string {
 	reasons := make(map[string]int)
 	for _, predicates := range f.FailedPredicates {
 		for _, pred := range predicates {
-			reasons[pred.GetReason()] += 1
+			reasons[pred.GetReason()]++
 		}
 	}
 
-	sortReasonsHistogram := func() []string {
-		reasonStrings := []string{}
-		for k, v := range reasons {
-			reasonStrings = append(reasonStrings, fmt.Sprintf("%v (%v)", k, v))
-		}
-		sort.Strings(reasonStrings)
-		return reasonStrings
+	histogram := make(reasonHistogram, 0, len(reasons))
+	for reason, count := range reasons {
+		histogram = append(histogram, reasonBucket{reason: reason, count: count})
+	}
+	sort.Sort(histogram)
+	reasonStrings := make([]string, 0, len(histogram))
+	for _, bucket := range histogram {
+		reasonStrings = append(reasonStrings, fmt.Sprintf("%v %v", bucket.count, bucket.reason))
 	}
-	reasonMsg := fmt.Sprintf(NoNodeAvailableMsg+": %v.", strings.Join(sortReasonsHistogram(), ", "))
-	return reasonMsg
+	return fmt.Sprintf("%v/%v "+NoNodeAvailableMsg+": %v.", f.NumAllNodes-f.NumFailedNodes, f.NumAllNodes, strings.Join(reasonStrings, ", "))
+}
+
+// IsNoNodesAvailable returns true if the error indicates that the cluster had
+// no nodes at all when the pod was scheduled. Callers can use this to back
+// off rather than retry immediately.
+func IsNoNodesAvailable(err error) bool {
+	return err == ErrNoNodesAvailable
+}
+
+// IsFitError returns the FitError if the error indicates that nodes existed
+// but none of them fit the pod, along with true. Callers can branch on this
+// to, for example, trigger cluster growth instead of backing off.
+func IsFitError(err error) (*FitError, bool) {
+	fitError, ok := err.(*FitError)
+	return fitError, ok
 }
 
 type genericScheduler struct {
@@ -75,18 +131,433 @@ type genericScheduler struct {
 	prioritizers          []algorithm.PriorityConfig
 	extenders             []algorithm.SchedulerExtender
 	pods                  algorithm.PodLister
-	lastNodeIndexLock     sync.Mutex
-	lastNodeIndex         uint64
+	// lastNodeIndexLock guards lastNodeIndex, which selectHost reads and
+	// increments exactly once per decision. Because there is no random
+	// source, a fresh scheduler yields a reproducible sequence of picks.
+	lastNodeIndexLock sync.Mutex
+	lastNodeIndex     uint64
 
 	cachedNodeInfoMap map[string]*schedulercache.NodeInfo
 
 	equivalenceCache *EquivalenceCache
+
+	stickyNode      algorithm.StickyNodeHint
+	stickyNodeBonus int
+
+	// maxFeasibleNodesToScore bounds how many feasible nodes are passed to
+	// prioritization. Zero means no cap.
+	maxFeasibleNodesToScore int
+	feasibleWindowLock      sync.Mutex
+	feasibleWindowStart     uint64
+
+	// percentageOfNodesToScore caps the scored nodes at a fraction of the
+	// feasible set instead of an absolute count, never going below
+	// minFeasibleNodesToScore. Zero (or 100 and above) disables the cap.
+	percentageOfNodesToScore int
+	minFeasibleNodesToScore  int
+
+	tracer algorithm.Tracer
+
+	// excludeNodes are skipped during predicate checking, e.g. nodes under
+	// transient maintenance that should not be cordoned.
+	excludeNodes sets.String
+
+	qosWeights algorithm.QoSWeights
+
+	tieBreaker TieBreaker
+
+	// minScore drops hosts scoring below it before host selection. Zero
+	// disables the floor.
+	minScore int
+
+	// softPredicates express "prefer not to, but allowed" constraints: a node
+	// failing one stays feasible but has softPredicatePenalty subtracted from
+	// its combined score per failed predicate.
+	softPredicates       map[string]algorithm.FitPredicate
+	softPredicatePenalty int
+
+	// predicateOrder, when set, lists predicate names in evaluation order so
+	// cheap high-rejection predicates run first; the per-node loop then stops
+	// at the first failure. Empty (the default) keeps map order and collects
+	// every failure reason.
+	predicateOrder []string
+
+	hooks SchedulingHooks
+
+	// normalizeScores clamps each priority function's scores to
+	// [0, maxPriorityScore] before weighting.
+	normalizeScores bool
+
+	// priorityConcurrency bounds how many priority and extender goroutines
+	// run at once during prioritization. Zero means GOMAXPROCS.
+	priorityConcurrency int
+}
+
+// maxPriorityScore is the highest score a priority function may assign to a
+// single node; see the PrioritizeNodes contract.
+const maxPriorityScore = 10
+
+// SetNormalizeScores configures clamping of each priority function's scores
+// to [0, maxPriorityScore] before they are weighted and combined, so a
+// misbehaving priority function or extender cannot silently dominate
+// placement.
+func (g *genericScheduler) SetNormalizeScores(normalize bool) {
+	g.normalizeScores = normalize
+}
+
+// clampScore bounds score to [0, maxPriorityScore] and logs when the named
+// priority function produced an out-of-range value.
+func clampScore(name, host string, score int) int {
+	if score >= 0 && score <= maxPriorityScore {
+		return score
+	}
+	clamped := score
+	if clamped < 0 {
+		clamped = 0
+	} else {
+		clamped = maxPriorityScore
+	}
+	glog.Warningf("Priority %q scored node %s at %d, clamping to %d", name, host, score, clamped)
+	return clamped
+}
+
+// normalizePriorityConfigs wraps each priority config so the scores it
+// produces are clamped to [0, maxPriorityScore].
+func normalizePriorityConfigs(configs []algorithm.PriorityConfig) []algorithm.PriorityConfig {
+	normalized := make([]algorithm.PriorityConfig, len(configs))
+	for i, config := range configs {
+		normalized[i] = config
+		name := config.Name
+		if function := config.Function; function != nil {
+			normalized[i].Function = func(pod *v1.Pod, nodeNameToInfo map[string]*schedulercache.NodeInfo, nodes []*v1.Node) (schedulerapi.HostPriorityList, error) {
+				result, err := function(pod, nodeNameToInfo, nodes)
+				if err != nil {
+					return result, err
+				}
+				for j := range result {
+					result[j].Score = clampScore(name, result[j].Host, result[j].Score)
+				}
+				return result, nil
+			}
+		}
+		if mapFunction := config.Map; mapFunction != nil {
+			normalized[i].Map = func(pod *v1.Pod, meta interface{}, nodeInfo *schedulercache.NodeInfo) (schedulerapi.HostPriority, error) {
+				hostPriority, err := mapFunction(pod, meta, nodeInfo)
+				if err != nil {
+					return hostPriority, err
+				}
+				hostPriority.Score = clampScore(name, hostPriority.Host, hostPriority.Score)
+				return hostPriority, nil
+			}
+		}
+		if reduceFunction := config.Reduce; reduceFunction != nil {
+			normalized[i].Reduce = func(pod *v1.Pod, meta interface{}, nodeNameToInfo map[string]*schedulercache.NodeInfo, result schedulerapi.HostPriorityList) error {
+				if err := reduceFunction(pod, meta, nodeNameToInfo, result); err != nil {
+					return err
+				}
+				for j := range result {
+					result[j].Score = clampScore(name, result[j].Host, result[j].Score)
+				}
+				return nil
+			}
+		}
+	}
+	return normalized
+}
+
+// SchedulingHooks are optional callbacks invoked at key points of a
+// scheduling cycle, e.g. to feed metrics or tracing. Nil callbacks are
+// skipped. The callbacks run synchronously on the scheduling goroutine and
+// are never invoked while internal locks are held.
+type SchedulingHooks struct {
+	// AfterFiltering is called with the number of feasible and failed nodes
+	// once predicate checking finishes.
+	AfterFiltering func(numFeasible, numFailed int)
+	// AfterPrioritizing is called with the combined scores of the feasible
+	// nodes once prioritization finishes.
+	AfterPrioritizing func(scores schedulerapi.HostPriorityList)
+	// AfterHostSelection is called with the winning host.
+	AfterHostSelection func(host string)
+}
+
+// SetPriorityConcurrency bounds how many priority and extender goroutines run
+// at once when this scheduler prioritizes nodes, protecting the process from
+// CPU oversubscription under heavy configurations. A non-positive limit (the
+// default) means GOMAXPROCS.
+func (g *genericScheduler) SetPriorityConcurrency(limit int) {
+	g.priorityConcurrency = limit
+}
+
+// SetSchedulingHooks configures callbacks observing the scheduling cycle.
+func (g *genericScheduler) SetSchedulingHooks(hooks SchedulingHooks) {
+	g.hooks = hooks
+}
+
+// SetPredicateOrder configures the order predicates are evaluated in, so that
+// cheap predicates with high rejection rates can run before expensive ones.
+// With an order configured, predicate evaluation for a node stops at the
+// first failure instead of collecting every failure reason; predicates the
+// order does not mention run last, in map order. An empty order (the default)
+// keeps the exhaustive behavior.
+func (g *genericScheduler) SetPredicateOrder(order []string) {
+	g.predicateOrder = order
+}
+
+// SetSoftPredicates configures predicates that downgrade a node's score
+// instead of rejecting it. Each failed soft predicate subtracts penalty from
+// the node's combined priority score.
+func (g *genericScheduler) SetSoftPredicates(predicates map[string]algorithm.FitPredicate, penalty int) {
+	g.softPredicates = predicates
+	g.softPredicatePenalty = penalty
+}
+
+// softPenalties converts per-node soft predicate failure counts into the
+// score penalties applied during prioritization.
+func (g *genericScheduler) softPenalties(softFailures map[string]int) map[string]int {
+	if len(softFailures) == 0 || g.softPredicatePenalty == 0 {
+		return nil
+	}
+	penalties := make(map[string]int, len(softFailures))
+	for name, failures := range softFailures {
+		penalties[name] = failures * g.softPredicatePenalty
+	}
+	return penalties
+}
+
+// SetMinScore configures a floor on the combined priority score: hosts
+// scoring below it are dropped before host selection, unless that would
+// leave no candidates, in which case the best-scoring hosts are kept. This
+// gives soft-preference semantics without a hard predicate.
+func (g *genericScheduler) SetMinScore(minScore int) {
+	g.minScore = minScore
+}
+
+// applyMinScore filters out hosts below the configured minimum score. When
+// every host is below the floor the top-scoring hosts are kept so the pod
+// can still schedule.
+func (g *genericScheduler) applyMinScore(priorityList schedulerapi.HostPriorityList) schedulerapi.HostPriorityList {
+	if g.minScore <= 0 {
+		return priorityList
+	}
+	filtered := make(schedulerapi.HostPriorityList, 0, len(priorityList))
+	for _, hostPriority := range priorityList {
+		if hostPriority.Score >= g.minScore {
+			filtered = append(filtered, hostPriority)
+		}
+	}
+	if len(filtered) > 0 {
+		return filtered
+	}
+	maxScore := 0
+	for _, hostPriority := range priorityList {
+		if hostPriority.Score > maxScore {
+			maxScore = hostPriority.Score
+		}
+	}
+	for _, hostPriority := range priorityList {
+		if hostPriority.Score == maxScore {
+			filtered = append(filtered, hostPriority)
+		}
+	}
+	return filtered
+}
+
+// HostScoreBreakdown records a single contributor's share of a host's
+// combined score, before and after weighting.
+type HostScoreBreakdown struct {
+	Raw      int
+	Weighted int
+}
+
+// ScoreBreakdown maps host name to the per-contributor scores that made up
+// its final combined score, for debugging priority weights. Contributors are
+// priority function names (or "Priority[i]" for unnamed configs),
+// "Extender[i]" entries, and the synthetic "Penalty" and "StickyNodeBonus"
+// entries. The Weighted values of a host sum to its final score. A nil
+// ScoreBreakdown disables recording, keeping the hot path free of overhead.
+type ScoreBreakdown map[string]map[string]HostScoreBreakdown
+
+// record accumulates a contributor's raw and weighted scores for a host.
+// Calling it on a nil breakdown is a no-op.
+func (b ScoreBreakdown) record(host, contributor string, raw, weighted int) {
+	if b == nil {
+		return
+	}
+	if b[host] == nil {
+		b[host] = map[string]HostScoreBreakdown{}
+	}
+	entry := b[host][contributor]
+	entry.Raw += raw
+	entry.Weighted += weighted
+	b[host][contributor] = entry
+}
+
+// priorityConfigName names a priority config for the score breakdown, falling
+// back to its position for configs registered without a name.
+func priorityConfigName(config algorithm.PriorityConfig, index int) string {
+	if len(config.Name) != 0 {
+		return config.Name
+	}
+	return fmt.Sprintf("Priority[%d]", index)
+}
+
+// TieBreaker selects one host from the hosts sharing the top score. The
+// slice is ordered by descending score, so all entries are equivalent; a
+// deterministic strategy (e.g. lowest name) makes placement reproducible.
+type TieBreaker func(topHosts []string) string
+
+// LowestNameTieBreaker deterministically breaks ties by choosing the host
+// with the lexicographically lowest name.
+func LowestNameTieBreaker(topHosts []string) string {
+	lowest := topHosts[0]
+	for _, host := range topHosts[1:] {
+		if host < lowest {
+			lowest = host
+		}
+	}
+	return lowest
+}
+
+// SetQoSWeights configures a hook that can override per-priority weights for
+// a specific pod before the priority results are combined. A nil hook (the
+// default) leaves the configured weights unchanged.
+func (g *genericScheduler) SetQoSWeights(qosWeights algorithm.QoSWeights) {
+	g.qosWeights = qosWeights
+}
+
+// applyQoSWeights returns a copy of the priority configs with any weight
+// overrides the hook provides for this pod applied. The input slice is not
+// mutated; priorities the hook does not mention keep their configured weight.
+func applyQoSWeights(pod *v1.Pod, priorityConfigs []algorithm.PriorityConfig, qosWeights algorithm.QoSWeights) []algorithm.PriorityConfig {
+	if qosWeights == nil {
+		return priorityConfigs
+	}
+	overrides := qosWeights(pod)
+	if len(overrides) == 0 {
+		return priorityConfigs
+	}
+	adjusted := make([]algorithm.PriorityConfig, len(priorityConfigs))
+	copy(adjusted, priorityConfigs)
+	for i := range adjusted {
+		if weight, ok := overrides[adjusted[i].Name]; ok {
+			adjusted[i].Weight = weight
+		}
+	}
+	return adjusted
+}
+
+// SetExcludeNodes configures a set of node names the scheduler skips during
+// filtering. Excluded nodes fail with a synthetic "node excluded" reason. A
+// nil or empty set (the default) excludes nothing.
+func (g *genericScheduler) SetExcludeNodes(nodes sets.String) {
+	g.excludeNodes = nodes
+}
+
+// SetTracer configures a tracer that receives one record per node considered
+// in each scheduling cycle. A nil tracer (the default) disables tracing.
+func (g *genericScheduler) SetTracer(tracer algorithm.Tracer) {
+	g.tracer = tracer
+}
+
+// traceFailedNodes reports every infeasible node to the tracer along with the
+// reasons its predicates failed.
+func (g *genericScheduler) traceFailedNodes(failedPredicateMap FailedPredicateMap) {
+	if g.tracer == nil {
+		return
+	}
+	for nodeName, failedPredicates := range failedPredicateMap {
+		reasons := make([]string, 0, len(failedPredicates))
+		for _, reason := range failedPredicates {
+			reasons = append(reasons, reason.GetReason())
+		}
+		g.tracer.RecordNode(nodeName, false, reasons, 0)
+	}
+}
+
+// traceScoredNodes reports every scored node to the tracer.
+func (g *genericScheduler) traceScoredNodes(priorityList schedulerapi.HostPriorityList) {
+	if g.tracer == nil {
+		return
+	}
+	for _, hostPriority := range priorityList {
+		g.tracer.RecordNode(hostPriority.Host, true, nil, hostPriority.Score)
+	}
+}
+
+// SetMaxFeasibleNodesToScore caps the number of feasible nodes handed to the
+// priority functions. The scored subset rotates round-robin across scheduling
+// cycles so that all feasible nodes are considered over time. A value of zero
+// disables the cap.
+func (g *genericScheduler) SetMaxFeasibleNodesToScore(max int) {
+	g.maxFeasibleNodesToScore = max
+}
+
+// SetPercentageOfNodesToScore caps the scored nodes at the given percentage
+// of the feasible set, but never below minNodes. On very large clusters this
+// keeps scoring cost proportional to the cap rather than the cluster size.
+// A percentage of zero or less, or of 100 and above, disables the cap.
+func (g *genericScheduler) SetPercentageOfNodesToScore(percentage, minNodes int) {
+	g.percentageOfNodesToScore = percentage
+	g.minFeasibleNodesToScore = minNodes
+}
+
+// numFeasibleNodesToScore resolves the configured absolute and percentage
+// caps against the size of the feasible set. Zero means no cap applies.
+func (g *genericScheduler) numFeasibleNodesToScore(numNodes int) int {
+	max := g.maxFeasibleNodesToScore
+	if g.percentageOfNodesToScore > 0 && g.percentageOfNodesToScore < 100 {
+		byPercentage := numNodes * g.percentageOfNodesToScore / 100
+		if byPercentage < g.minFeasibleNodesToScore {
+			byPercentage = g.minFeasibleNodesToScore
+		}
+		if max <= 0 || byPercentage < max {
+			max = byPercentage
+		}
+	}
+	return max
+}
+
+// capFeasibleNodes returns a bounded, rotating window over the feasible nodes
+// when an absolute or percentage cap is set, and the full list otherwise.
+func (g *genericScheduler) capFeasibleNodes(nodes []*v1.Node) []*v1.Node {
+	max := g.numFeasibleNodesToScore(len(nodes))
+	if max <= 0 || len(nodes) <= max {
+		return nodes
+	}
+
+	g.feasibleWindowLock.Lock()
+	start := int(g.feasibleWindowStart % uint64(len(nodes)))
+	g.feasibleWindowStart += uint64(max)
+	g.feasibleWindowLock.Unlock()
+
+	capped := make([]*v1.Node, 0, max)
+	for i := 0; i < max; i++ {
+		capped = append(capped, nodes[(start+i)%len(nodes)])
+	}
+	return capped
+}
+
+// SetStickyNodeHint configures the scheduler to boost the score of the node
+// returned by the given hint by the given bonus during prioritization. A nil
+// hint disables the behavior.
+func (g *genericScheduler) SetStickyNodeHint(stickyNode algorithm.StickyNodeHint, bonus int) {
+	g.stickyNode = stickyNode
+	g.stickyNodeBonus = bonus
 }
 
 // Schedule tries to schedule the given pod to one of node in the node list.
 // If it succeeds, it will return the name of the node.
 // If it fails, it will return a Fiterror error with reasons.
 func (g *genericScheduler) Schedule(pod *v1.Pod, nodeLister algorithm.NodeLister) (string, error) {
+	return g.ScheduleWithContext(context.Background(), pod, nodeLister)
+}
+
+// ScheduleWithContext is Schedule with a caller-supplied context that bounds
+// the scheduling cycle: when the context is cancelled or its deadline passes,
+// predicate and priority evaluation stop early and the context's error is
+// returned. Extenders are only invoked while the context is live; bounding an
+// individual extender call is the extender's own responsibility.
+func (g *genericScheduler) ScheduleWithContext(ctx context.Context, pod *v1.Pod, nodeLister algorithm.NodeLister) (string, error) {
 	trace := utiltrace.New(fmt.Sprintf("Scheduling %s/%s", pod.Namespace, pod.Name))
 	defer trace.LogIfLong(100 * time.Millisecond)
 
@@ -107,31 +578,158 @@ func (g *genericScheduler) Schedule(pod *v1.Pod, nodeLister algorithm.NodeLister
 	// TODO(harryz) Check if equivalenceCache is enabled and call scheduleWithEquivalenceClass here
 
 	trace.Step("Computing predicates")
-	filteredNodes, failedPredicateMap, err := findNodesThatFit(pod, g.cachedNodeInfoMap, nodes, g.predicates, g.extenders, g.predicateMetaProducer)
+	filteredNodes, failedPredicateMap, softFailures, err := findNodesThatFit(ctx, pod, g.cachedNodeInfoMap, nodes, g.predicates, g.predicateOrder, g.softPredicates, g.extenders, g.predicateMetaProducer, g.excludeNodes)
 	if err != nil {
 		return "", err
 	}
+	g.traceFailedNodes(failedPredicateMap)
+	if g.hooks.AfterFiltering != nil {
+		g.hooks.AfterFiltering(len(filteredNodes), len(failedPredicateMap))
+	}
 
 	if len(filteredNodes) == 0 {
+		// Log the per-node failures once, here, rather than in FitError.Error:
+		// the error string may be rendered many times (events, retries).
+		if glog.V(2) {
+			for node, predicates := range failedPredicateMap {
+				for _, pred := range predicates {
+					glog.Infof("Pod %v/%v does not fit on node %v: %v", pod.Namespace, pod.Name, node, pred.GetReason())
+				}
+			}
+		}
 		return "", &FitError{
 			Pod:              pod,
+			NumAllNodes:      len(nodes),
+			NumFailedNodes:   len(failedPredicateMap),
 			FailedPredicates: failedPredicateMap,
 		}
 	}
 
 	trace.Step("Prioritizing")
+	filteredNodes = g.capFeasibleNodes(filteredNodes)
 	metaPrioritiesInterface := g.priorityMetaProducer(pod, g.cachedNodeInfoMap)
-	priorityList, err := PrioritizeNodes(pod, g.cachedNodeInfoMap, metaPrioritiesInterface, g.prioritizers, filteredNodes, g.extenders)
+	prioritizers := applyQoSWeights(pod, g.prioritizers, g.qosWeights)
+	if g.normalizeScores {
+		prioritizers = normalizePriorityConfigs(prioritizers)
+	}
+	priorityList, err := PrioritizeNodes(ctx, pod, g.cachedNodeInfoMap, metaPrioritiesInterface, prioritizers, filteredNodes, g.extenders, g.stickyNode, g.stickyNodeBonus, g.softPenalties(softFailures), g.priorityConcurrency, nil)
 	if err != nil {
 		return "", err
 	}
+	g.traceScoredNodes(priorityList)
+	if g.hooks.AfterPrioritizing != nil {
+		g.hooks.AfterPrioritizing(priorityList)
+	}
 
 	trace.Step("Selecting host")
-	return g.selectHost(priorityList)
+	return g.selectHost(g.applyMinScore(priorityList))
+}
+
+// SchedulingExplanation aggregates the outcome of a scheduling cycle for a
+// single pod: which nodes were feasible, why the others were not, how the
+// feasible nodes scored, and which host would have been chosen.
+type SchedulingExplanation struct {
+	// FeasibleNodes are the names of the nodes that passed all predicates.
+	FeasibleNodes []string
+	// FailedPredicates maps infeasible node names to the reasons their
+	// predicates failed.
+	FailedPredicates FailedPredicateMap
+	// Scores holds the final combined score of every feasible node.
+	Scores schedulerapi.HostPriorityList
+	// SuggestedHost is the node the pod would have been scheduled to, or the
+	// empty string if no node was feasible.
+	SuggestedHost string
+}
+
+// Explain runs a full scheduling cycle for the pod without binding it and
+// without advancing the scheduler's round-robin state, returning the
+// intermediate results so callers can report why a pod is or is not
+// schedulable. A pod with no feasible nodes yields an explanation rather than
+// an error.
+func (g *genericScheduler) Explain(pod *v1.Pod, nodeLister algorithm.NodeLister) (*SchedulingExplanation, error) {
+	return g.explain(pod, nodeLister, nil)
+}
+
+// ExplainScores is Explain with score debugging enabled: it additionally
+// returns, per feasible node, the contribution of every priority function and
+// extender to the node's final score. The bookkeeping only happens on this
+// path, so ordinary scheduling pays nothing for it.
+func (g *genericScheduler) ExplainScores(pod *v1.Pod, nodeLister algorithm.NodeLister) (*SchedulingExplanation, ScoreBreakdown, error) {
+	breakdown := ScoreBreakdown{}
+	explanation, err := g.explain(pod, nodeLister, breakdown)
+	if err != nil {
+		return nil, nil, err
+	}
+	return explanation, breakdown, nil
+}
+
+func (g *genericScheduler) explain(pod *v1.Pod, nodeLister algorithm.NodeLister, breakdown ScoreBreakdown) (*SchedulingExplanation, error) {
+	nodes, err := nodeLister.List()
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, ErrNoNodesAvailable
+	}
+
+	err = g.cache.UpdateNodeNameToInfoMap(g.cachedNodeInfoMap)
+	if err != nil {
+		return nil, err
+	}
+
+	filteredNodes, failedPredicateMap, softFailures, err := findNodesThatFit(context.Background(), pod, g.cachedNodeInfoMap, nodes, g.predicates, g.predicateOrder, g.softPredicates, g.extenders, g.predicateMetaProducer, g.excludeNodes)
+	if err != nil {
+		return nil, err
+	}
+
+	explanation := &SchedulingExplanation{
+		FeasibleNodes:    make([]string, 0, len(filteredNodes)),
+		FailedPredicates: failedPredicateMap,
+	}
+	for _, node := range filteredNodes {
+		explanation.FeasibleNodes = append(explanation.FeasibleNodes, node.Name)
+	}
+	if len(filteredNodes) == 0 {
+		return explanation, nil
+	}
+
+	metaPrioritiesInterface := g.priorityMetaProducer(pod, g.cachedNodeInfoMap)
+	prioritizers := applyQoSWeights(pod, g.prioritizers, g.qosWeights)
+	if g.normalizeScores {
+		prioritizers = normalizePriorityConfigs(prioritizers)
+	}
+	priorityList, err := PrioritizeNodes(context.Background(), pod, g.cachedNodeInfoMap, metaPrioritiesInterface, prioritizers, filteredNodes, g.extenders, g.stickyNode, g.stickyNodeBonus, g.softPenalties(softFailures), g.priorityConcurrency, breakdown)
+	if err != nil {
+		return nil, err
+	}
+	explanation.Scores = priorityList
+
+	sorted := make(schedulerapi.HostPriorityList, len(priorityList))
+	copy(sorted, priorityList)
+	sort.Sort(sort.Reverse(sorted))
+	explanation.SuggestedHost = sorted[0].Host
+	return explanation, nil
+}
+
+// Preview runs filtering and prioritization for the pod without selecting a
+// host and without advancing the scheduler's round-robin state. It returns
+// the feasible nodes ranked from highest to lowest score together with the
+// reasons infeasible nodes were rejected, leaving host selection to the
+// caller.
+func (g *genericScheduler) Preview(pod *v1.Pod, nodeLister algorithm.NodeLister) (schedulerapi.HostPriorityList, FailedPredicateMap, error) {
+	explanation, err := g.Explain(pod, nodeLister)
+	if err != nil {
+		return nil, nil, err
+	}
+	ranked := make(schedulerapi.HostPriorityList, len(explanation.Scores))
+	copy(ranked, explanation.Scores)
+	sort.Sort(sort.Reverse(ranked))
+	return ranked, explanation.FailedPredicates, nil
 }
 
 // selectHost takes a prioritized list of nodes and then picks one
-// in a round-robin manner from the nodes that had the highest score.
+// from the nodes that had the highest score: via the configured tie breaker
+// when one is set, in a round-robin manner otherwise.
 func (g *genericScheduler) selectHost(priorityList schedulerapi.HostPriorityList) (string, error) {
 	if len(priorityList) == 0 {
 		return "", fmt.Errorf("empty priorityList")
@@ -141,27 +739,65 @@ func (g *genericScheduler) selectHost(priorityList schedulerapi.HostPriorityList
 	maxScore := priorityList[0].Score
 	firstAfterMaxScore := sort.Search(len(priorityList), func(i int) bool { return priorityList[i].Score < maxScore })
 
-	g.lastNodeIndexLock.Lock()
-	ix := int(g.lastNodeIndex % uint64(firstAfterMaxScore))
-	g.lastNodeIndex++
-	g.lastNodeIndexLock.Unlock()
+	var host string
+	if g.tieBreaker != nil && firstAfterMaxScore > 1 {
+		topHosts := make([]string, 0, firstAfterMaxScore)
+		for i := 0; i < firstAfterMaxScore; i++ {
+			topHosts = append(topHosts, priorityList[i].Host)
+		}
+		host = g.tieBreaker(topHosts)
+	} else {
+		g.lastNodeIndexLock.Lock()
+		ix := int(g.lastNodeIndex % uint64(firstAfterMaxScore))
+		g.lastNodeIndex++
+		g.lastNodeIndexLock.Unlock()
+		host = priorityList[ix].Host
+	}
 
-	return priorityList[ix].Host, nil
+	// The hook runs outside lastNodeIndexLock so user code cannot stall
+	// concurrent host selections.
+	if g.hooks.AfterHostSelection != nil {
+		g.hooks.AfterHostSelection(host)
+	}
+	return host, nil
 }
 
 // Filters the nodes to find the ones that fit based on the given predicate functions
 // Each node is passed through the predicate functions to determine if it is a fit
+// Soft predicates are evaluated on the fitting nodes and their failure counts are
+// returned per node instead of excluding the node.
+// The context bounds the filtering: once it is cancelled, remaining nodes are
+// not checked and the context's error is returned.
 func findNodesThatFit(
+	ctx context.Context,
 	pod *v1.Pod,
 	nodeNameToInfo map[string]*schedulercache.NodeInfo,
 	nodes []*v1.Node,
 	predicateFuncs map[string]algorithm.FitPredicate,
+	predicateOrder []string,
+	softPredicateFuncs map[string]algorithm.FitPredicate,
 	extenders []algorithm.SchedulerExtender,
 	metadataProducer algorithm.MetadataProducer,
-) ([]*v1.Node, FailedPredicateMap, error) {
+	excludeNodes sets.String,
+) ([]*v1.Node, FailedPredicateMap, map[string]int, error) {
 	var filtered []*v1.Node
 	failedPredicateMap := FailedPredicateMap{}
 
+	if excludeNodes.Len() > 0 {
+		remaining := make([]*v1.Node, 0, len(nodes))
+		for _, node := range nodes {
+			if excludeNodes.Has(node.Name) {
+				failedPredicateMap[node.Name] = []algorithm.PredicateFailureReason{predicates.ErrNodeExcluded}
+				continue
+			}
+			remaining = append(remaining, node)
+		}
+		nodes = remaining
+	}
+
+	// We can use the same metadata producer for all nodes.
+	meta := metadataProducer(pod, nodeNameToInfo)
+
 	if len(predicateFuncs) == 0 {
 		filtered = nodes
 	} else {
@@ -172,11 +808,12 @@ func findNodesThatFit(
 		var predicateResultLock sync.Mutex
 		var filteredLen int32
 
-		// We can use the same metadata producer for all nodes.
-		meta := metadataProducer(pod, nodeNameToInfo)
 		checkNode := func(i int) {
+			if ctx.Err() != nil {
+				return
+			}
 			nodeName := nodes[i].Name
-			fits, failedPredicates, err := podFitsOnNode(pod, meta, nodeNameToInfo[nodeName], predicateFuncs)
+			fits, failedPredicates, err := podFitsOnNode(pod, meta, nodeNameToInfo[nodeName], predicateFuncs, predicateOrder)
 			if err != nil {
 				predicateResultLock.Lock()
 				errs = append(errs, err)
@@ -194,15 +831,27 @@ func findNodesThatFit(
 		workqueue.Parallelize(16, len(nodes), checkNode)
 		filtered = filtered[:filteredLen]
 		if len(errs) > 0 {
-			return []*v1.Node{}, FailedPredicateMap{}, errors.NewAggregate(errs)
+			return []*v1.Node{}, FailedPredicateMap{}, nil, errors.NewAggregate(errs)
+		}
+		if err := ctx.Err(); err != nil {
+			return []*v1.Node{}, FailedPredicateMap{}, nil, err
 		}
 	}
 
 	if len(filtered) > 0 && len(extenders) != 0 {
 		for _, extender := range extenders {
+			if err := ctx.Err(); err != nil {
+				return []*v1.Node{}, FailedPredicateMap{}, nil, err
+			}
 			filteredList, failedMap, err := extender.Filter(pod, filtered)
 			if err != nil {
-				return []*v1.Node{}, FailedPredicateMap{}, err
+				if !extender.IsFilterLenient() {
+					return []*v1.Node{}, FailedPredicateMap{}, nil, err
+				}
+				// A lenient extender is not authoritative: its failure leaves
+				// the candidate nodes unchanged.
+				glog.Warningf("Skipping failed lenient filter extender for pod %v/%v: %v", pod.Namespace, pod.Name, err)
+				continue
 			}
 
 			for failedNodeName, failedMsg := range failedMap {
@@ -211,26 +860,95 @@ func findNodesThatFit(
 				}
 				failedPredicateMap[failedNodeName] = append(failedPredicateMap[failedNodeName], predicates.NewFailureReason(failedMsg))
 			}
-			filtered = filteredList
+			filtered = sanitizeExtenderNodes(pod, filtered, filteredList)
 			if len(filtered) == 0 {
 				break
 			}
 		}
 	}
-	return filtered, failedPredicateMap, nil
+
+	// Soft predicates never exclude a node; a feasible node failing one only
+	// accumulates failures that PrioritizeNodes turns into score penalties.
+	var softFailures map[string]int
+	if len(softPredicateFuncs) > 0 && len(filtered) > 0 {
+		softFailures = map[string]int{}
+		for _, node := range filtered {
+			_, failedPredicates, err := podFitsOnNode(pod, meta, nodeNameToInfo[node.Name], softPredicateFuncs, nil)
+			if err != nil {
+				return []*v1.Node{}, FailedPredicateMap{}, nil, err
+			}
+			if len(failedPredicates) > 0 {
+				softFailures[node.Name] = len(failedPredicates)
+			}
+		}
+	}
+	return filtered, failedPredicateMap, softFailures, nil
+}
+
+// sanitizeExtenderNodes restricts an extender's filter result to the nodes
+// the extender was given: nodes outside that set are dropped with a logged
+// warning, and duplicates are collapsed to their first occurrence. This keeps
+// a buggy extender from growing the feasible set or listing a node twice.
+func sanitizeExtenderNodes(pod *v1.Pod, input []*v1.Node, returned []*v1.Node) []*v1.Node {
+	candidates := make(map[string]bool, len(input))
+	for _, node := range input {
+		candidates[node.Name] = true
+	}
+	sanitized := make([]*v1.Node, 0, len(returned))
+	seen := make(map[string]bool, len(returned))
+	for _, node := range returned {
+		if !candidates[node.Name] {
+			glog.Warningf("Extender returned node %v that was not a candidate for pod %v/%v, dropping it", node.Name, pod.Namespace, pod.Name)
+			continue
+		}
+		if seen[node.Name] {
+			glog.Warningf("Extender returned node %v more than once for pod %v/%v, dropping the duplicate", node.Name, pod.Namespace, pod.Name)
+			continue
+		}
+		seen[node.Name] = true
+		sanitized = append(sanitized, node)
+	}
+	return sanitized
+}
+
+// orderedPredicateKeys returns the predicate names to evaluate, listing the
+// configured order first and any predicates it does not mention afterwards in
+// map order. Order entries without a matching predicate are skipped.
+func orderedPredicateKeys(predicateFuncs map[string]algorithm.FitPredicate, order []string) []string {
+	keys := make([]string, 0, len(predicateFuncs))
+	ordered := make(map[string]bool, len(order))
+	for _, key := range order {
+		if _, ok := predicateFuncs[key]; ok && !ordered[key] {
+			keys = append(keys, key)
+			ordered[key] = true
+		}
+	}
+	for key := range predicateFuncs {
+		if !ordered[key] {
+			keys = append(keys, key)
+		}
+	}
+	return keys
 }
 
 // Checks whether node with a given name and NodeInfo satisfies all predicateFuncs.
-func podFitsOnNode(pod *v1.Pod, meta interface{}, info *schedulercache.NodeInfo, predicateFuncs map[string]algorithm.FitPredicate) (bool, []algorithm.PredicateFailureReason, error) {
+// When order is non-empty the named predicates run first, in that order, and
+// evaluation stops at the first failure so cheap high-rejection predicates can
+// shield expensive ones. Without an order every predicate runs and all
+// failure reasons are collected.
+func podFitsOnNode(pod *v1.Pod, meta interface{}, info *schedulercache.NodeInfo, predicateFuncs map[string]algorithm.FitPredicate, order []string) (bool, []algorithm.PredicateFailureReason, error) {
 	var failedPredicates []algorithm.PredicateFailureReason
-	for _, predicate := range predicateFuncs {
-		fit, reasons, err := predicate(pod, meta, info)
+	for _, predicateKey := range orderedPredicateKeys(predicateFuncs, order) {
+		fit, reasons, err := predicateFuncs[predicateKey](pod, meta, info)
 		if err != nil {
 			err := fmt.Errorf("SchedulerPredicates failed due to %v, which is unexpected.", err)
 			return false, []algorithm.PredicateFailureReason{}, err
 		}
 		if !fit {
 			failedPredicates = append(failedPredicates, reasons...)
+			if len(order) > 0 {
+				break
+			}
 		}
 	}
 	return len(failedPredicates) == 0, failedPredicates, nil
@@ -242,13 +960,27 @@ func podFitsOnNode(pod *v1.Pod, meta interface{}, info *schedulercache.NodeInfo,
 // Each priority function can also have its own weight
 // The node scores returned by the priority function are multiplied by the weights to get weighted scores
 // All scores are finally combined (added) to get the total weighted scores of all nodes
+// Penalties (e.g. from failed soft predicates) are subtracted from the combined
+// score of the named nodes
+// The context bounds the work: once it is cancelled, priority functions that
+// have not yet run are skipped and the context's error is returned.
+// At most concurrency priority and extender goroutines run at once; a
+// non-positive value means GOMAXPROCS.
+// A non-nil breakdown additionally receives each contributor's share of every
+// host's final score; nil skips the bookkeeping entirely.
 func PrioritizeNodes(
+	ctx context.Context,
 	pod *v1.Pod,
 	nodeNameToInfo map[string]*schedulercache.NodeInfo,
 	meta interface{},
 	priorityConfigs []algorithm.PriorityConfig,
 	nodes []*v1.Node,
 	extenders []algorithm.SchedulerExtender,
+	stickyNode algorithm.StickyNodeHint,
+	stickyNodeBonus int,
+	penalties map[string]int,
+	concurrency int,
+	breakdown ScoreBreakdown,
 ) (schedulerapi.HostPriorityList, error) {
 	// If no priority configs are provided, then the EqualPriority function is applied
 	// This is required to generate the priority list in the required format
@@ -259,9 +991,14 @@ func PrioritizeNodes(
 			if err != nil {
 				return nil, err
 			}
+			breakdown.record(hostPriority.Host, "EqualPriority", hostPriority.Score, hostPriority.Score)
+			if penalty := penalties[hostPriority.Host]; penalty != 0 {
+				breakdown.record(hostPriority.Host, "Penalty", -penalty, -penalty)
+			}
+			hostPriority.Score -= penalties[hostPriority.Host]
 			result = append(result, hostPriority)
 		}
-		return result, nil
+		return applyStickyNodeBonusWithBreakdown(pod, result, stickyNode, stickyNodeBonus, breakdown), nil
 	}
 
 	var (
@@ -275,6 +1012,15 @@ func PrioritizeNodes(
 		errs = append(errs, err)
 	}
 
+	// Each priority function and extender runs in its own goroutine; the
+	// semaphore keeps at most concurrency of them executing simultaneously.
+	if concurrency <= 0 {
+		concurrency = runtime.GOMAXPROCS(0)
+	}
+	sem := make(chan struct{}, concurrency)
+	acquire := func() { sem <- struct{}{} }
+	release := func() { <-sem }
+
 	results := make([]schedulerapi.HostPriorityList, 0, len(priorityConfigs))
 	for range priorityConfigs {
 		results = append(results, nil)
@@ -285,6 +1031,11 @@ func PrioritizeNodes(
 			wg.Add(1)
 			go func(index int, config algorithm.PriorityConfig) {
 				defer wg.Done()
+				acquire()
+				defer release()
+				if ctx.Err() != nil {
+					return
+				}
 				var err error
 				results[index], err = config.Function(pod, nodeNameToInfo, nodes)
 				if err != nil {
@@ -296,6 +1047,9 @@ func PrioritizeNodes(
 		}
 	}
 	processNode := func(index int) {
+		if ctx.Err() != nil {
+			return
+		}
 		nodeInfo := nodeNameToInfo[nodes[index].Name]
 		var err error
 		for i := range priorityConfigs {
@@ -317,6 +1071,8 @@ func PrioritizeNodes(
 		wg.Add(1)
 		go func(index int, config algorithm.PriorityConfig) {
 			defer wg.Done()
+			acquire()
+			defer release()
 			if err := config.Reduce(pod, meta, nodeNameToInfo, results[index]); err != nil {
 				appendError(err)
 			}
@@ -327,6 +1083,9 @@ func PrioritizeNodes(
 	if len(errs) != 0 {
 		return schedulerapi.HostPriorityList{}, errors.NewAggregate(errs)
 	}
+	if err := ctx.Err(); err != nil {
+		return schedulerapi.HostPriorityList{}, err
+	}
 
 	// Summarize all scores.
 	result := make(schedulerapi.HostPriorityList, 0, len(nodes))
@@ -334,36 +1093,56 @@ func PrioritizeNodes(
 	for i := range nodes {
 		result = append(result, schedulerapi.HostPriority{Host: nodes[i].Name, Score: 0})
 		for j := range priorityConfigs {
-			result[i].Score += results[j][i].Score * priorityConfigs[j].Weight
+			weighted := results[j][i].Score * priorityConfigs[j].Weight
+			result[i].Score += weighted
+			breakdown.record(nodes[i].Name, priorityConfigName(priorityConfigs[j], j), results[j][i].Score, weighted)
 		}
+		if penalty := penalties[nodes[i].Name]; penalty != 0 {
+			breakdown.record(nodes[i].Name, "Penalty", -penalty, -penalty)
+		}
+		result[i].Score -= penalties[nodes[i].Name]
 	}
 
 	if len(extenders) != 0 && nodes != nil {
 		combinedScores := make(map[string]int, len(nodeNameToInfo))
-		for _, extender := range extenders {
+		for extenderIndex, extender := range extenders {
 			wg.Add(1)
-			go func(ext algorithm.SchedulerExtender) {
+			go func(index int, ext algorithm.SchedulerExtender) {
 				defer wg.Done()
+				acquire()
+				defer release()
+				if ctx.Err() != nil {
+					return
+				}
 				prioritizedList, weight, err := ext.Prioritize(pod, nodes)
 				if err != nil {
-					// Prioritization errors from extender can be ignored, let k8s/other extenders determine the priorities
+					if ext.IsHardError() {
+						appendError(err)
+					}
+					// Prioritization errors from other extenders can be ignored, let k8s/other extenders determine the priorities
 					return
 				}
 				mu.Lock()
 				for i := range *prioritizedList {
 					host, score := (*prioritizedList)[i].Host, (*prioritizedList)[i].Score
 					combinedScores[host] += score * weight
+					breakdown.record(host, fmt.Sprintf("Extender[%d]", index), score, score*weight)
 				}
 				mu.Unlock()
-			}(extender)
+			}(extenderIndex, extender)
 		}
 		// wait for all go routines to finish
 		wg.Wait()
+		if len(errs) != 0 {
+			return schedulerapi.HostPriorityList{}, errors.NewAggregate(errs)
+		}
 		for i := range result {
 			result[i].Score += combinedScores[result[i].Host]
 		}
 	}
 
+	result = applyStickyNodeBonusWithBreakdown(pod, result, stickyNode, stickyNodeBonus, breakdown)
+
 	if glog.V(10) {
 		for i := range result {
 			glog.V(10).Infof("Host %s => Score %d", result[i].Host, result[i].Score)
@@ -372,6 +1151,45 @@ func PrioritizeNodes(
 	return result, nil
 }
 
+// applyStickyNodeBonus boosts the score of the node named by the sticky node
+// hint, if any. The result list only contains nodes that passed predicate
+// checking, so an infeasible (or empty) hint leaves the scores untouched.
+func applyStickyNodeBonus(pod *v1.Pod, result schedulerapi.HostPriorityList, stickyNode algorithm.StickyNodeHint, bonus int) schedulerapi.HostPriorityList {
+	if stickyNode == nil || bonus == 0 {
+		return result
+	}
+	nodeName := stickyNode(pod)
+	if len(nodeName) == 0 {
+		return result
+	}
+	for i := range result {
+		if result[i].Host == nodeName {
+			result[i].Score += bonus
+			break
+		}
+	}
+	return result
+}
+
+// applyStickyNodeBonusWithBreakdown applies the sticky node bonus and, when a
+// breakdown is being recorded, attributes the delta to "StickyNodeBonus".
+func applyStickyNodeBonusWithBreakdown(pod *v1.Pod, result schedulerapi.HostPriorityList, stickyNode algorithm.StickyNodeHint, bonus int, breakdown ScoreBreakdown) schedulerapi.HostPriorityList {
+	if breakdown == nil {
+		return applyStickyNodeBonus(pod, result, stickyNode, bonus)
+	}
+	before := make(map[string]int, len(result))
+	for _, hostPriority := range result {
+		before[hostPriority.Host] = hostPriority.Score
+	}
+	result = applyStickyNodeBonus(pod, result, stickyNode, bonus)
+	for _, hostPriority := range result {
+		if delta := hostPriority.Score - before[hostPriority.Host]; delta != 0 {
+			breakdown.record(hostPriority.Host, "StickyNodeBonus", delta, delta)
+		}
+	}
+	return result
+}
+
 // EqualPriority is a prioritizer function that gives an equal weight of one to all nodes
 func EqualPriorityMap(_ *v1.Pod, _ interface{}, nodeInfo *schedulercache.NodeInfo) (schedulerapi.HostPriority, error) {
 	node := nodeInfo.Node()
@@ -384,14 +1202,18 @@ func EqualPriorityMap(_ *v1.Pod, _ interface{}, nodeInfo *schedulercache.NodeInf
 	}, nil
 }
 
+// NewGenericScheduler creates a genericScheduler. An optional tie breaker may
+// be passed as a trailing argument; when omitted (or nil) ties among
+// top-scoring nodes are broken in the default round-robin manner.
 func NewGenericScheduler(
 	cache schedulercache.Cache,
 	predicates map[string]algorithm.FitPredicate,
 	predicateMetaProducer algorithm.MetadataProducer,
 	prioritizers []algorithm.PriorityConfig,
 	priorityMetaProducer algorithm.MetadataProducer,
-	extenders []algorithm.SchedulerExtender) algorithm.ScheduleAlgorithm {
-	return &genericScheduler{
+	extenders []algorithm.SchedulerExtender,
+	tieBreaker ...TieBreaker) algorithm.ScheduleAlgorithm {
+	g := &genericScheduler{
 		cache:                 cache,
 		predicates:            predicates,
 		predicateMetaProducer: predicateMetaProducer,
@@ -400,4 +1222,8 @@ func NewGenericScheduler(
 		extenders:             extenders,
 		cachedNodeInfoMap:     make(map[string]*schedulercache.NodeInfo),
 	}
+	if len(tieBreaker) > 0 {
+		g.tieBreaker = tieBreaker[0]
+	}
+	return g
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package scheduler
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"reflect"
@@ -177,6 +178,106 @@ func TestSelectHost(t *testing.T) {
 	}
 }
 
+func TestApplyMinScore(t *testing.T) {
+	list := schedulerapi.HostPriorityList{
+		{Host: "machine1", Score: 8},
+		{Host: "machine2", Score: 3},
+		{Host: "machine3", Score: 5},
+	}
+
+	// no floor keeps the list untouched
+	g := &genericScheduler{}
+	if got := g.applyMinScore(list); len(got) != 3 {
+		t.Errorf("expected full list without a floor, got %v", got)
+	}
+
+	// hosts below the floor are dropped
+	g.SetMinScore(5)
+	got := g.applyMinScore(list)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 hosts at or above the floor, got %v", got)
+	}
+	for _, hostPriority := range got {
+		if hostPriority.Score < 5 {
+			t.Errorf("host %s below the floor survived: %v", hostPriority.Host, got)
+		}
+	}
+
+	// a floor that would empty the list keeps the best-scoring hosts
+	g.SetMinScore(100)
+	got = g.applyMinScore(list)
+	if len(got) != 1 || got[0].Host != "machine1" {
+		t.Errorf("expected the best host to survive an unsatisfiable floor, got %v", got)
+	}
+}
+
+func TestSelectHostDeterministic(t *testing.T) {
+	list := []schedulerapi.HostPriority{
+		{Host: "machine1", Score: 2},
+		{Host: "machine2", Score: 2},
+		{Host: "machine3", Score: 2},
+	}
+
+	// a fresh scheduler produces the same sequence of picks every run
+	var firstRun []string
+	for run := 0; run < 3; run++ {
+		scheduler := genericScheduler{}
+		picks := make([]string, 0, 5)
+		for i := 0; i < 5; i++ {
+			got, err := scheduler.selectHost(list)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			picks = append(picks, got)
+		}
+		if firstRun == nil {
+			firstRun = picks
+		} else if !reflect.DeepEqual(firstRun, picks) {
+			t.Errorf("run %d diverged: expected %v, got %v", run, firstRun, picks)
+		}
+	}
+}
+
+func TestSelectHostTieBreaker(t *testing.T) {
+	list := []schedulerapi.HostPriority{
+		{Host: "machine3", Score: 2},
+		{Host: "machine1", Score: 2},
+		{Host: "machine2", Score: 2},
+		{Host: "machine4", Score: 1},
+	}
+
+	// a deterministic tie breaker always returns the same host
+	scheduler := genericScheduler{tieBreaker: LowestNameTieBreaker}
+	for i := 0; i < 10; i++ {
+		got, err := scheduler.selectHost(list)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != "machine1" {
+			t.Errorf("expected machine1, got %s", got)
+		}
+	}
+
+	// the tie breaker only runs on actual ties
+	scheduler = genericScheduler{tieBreaker: func(topHosts []string) string {
+		t.Errorf("tie breaker invoked without a tie: %v", topHosts)
+		return topHosts[0]
+	}}
+	single := []schedulerapi.HostPriority{
+		{Host: "machine1", Score: 2},
+		{Host: "machine2", Score: 1},
+	}
+	if got, err := scheduler.selectHost(single); err != nil || got != "machine1" {
+		t.Errorf("expected machine1, got %s (%v)", got, err)
+	}
+
+	// nil keeps the round-robin default covered by TestSelectHost
+	scheduler = genericScheduler{}
+	if _, err := scheduler.selectHost(list); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
 func TestGenericScheduler(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -197,7 +298,9 @@ func TestGenericScheduler(t *testing.T) {
 			pod:          &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "2"}},
 			name:         "test 1",
 			wErr: &FitError{
-				Pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "2"}},
+				Pod:            &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "2"}},
+				NumAllNodes:    2,
+				NumFailedNodes: 2,
 				FailedPredicates: FailedPredicateMap{
 					"machine1": []algorithm.PredicateFailureReason{algorithmpredicates.ErrFakePredicate},
 					"machine2": []algorithm.PredicateFailureReason{algorithmpredicates.ErrFakePredicate},
@@ -257,7 +360,9 @@ func TestGenericScheduler(t *testing.T) {
 			expectsErr:   true,
 			name:         "test 7",
 			wErr: &FitError{
-				Pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "2"}},
+				Pod:            &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "2"}},
+				NumAllNodes:    3,
+				NumFailedNodes: 3,
 				FailedPredicates: FailedPredicateMap{
 					"3": []algorithm.PredicateFailureReason{algorithmpredicates.ErrFakePredicate},
 					"2": []algorithm.PredicateFailureReason{algorithmpredicates.ErrFakePredicate},
@@ -287,7 +392,9 @@ func TestGenericScheduler(t *testing.T) {
 			expectsErr:   true,
 			name:         "test 8",
 			wErr: &FitError{
-				Pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "2"}},
+				Pod:            &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "2"}},
+				NumAllNodes:    2,
+				NumFailedNodes: 2,
 				FailedPredicates: FailedPredicateMap{
 					"1": []algorithm.PredicateFailureReason{algorithmpredicates.ErrFakePredicate},
 					"2": []algorithm.PredicateFailureReason{algorithmpredicates.ErrFakePredicate},
@@ -318,6 +425,49 @@ func TestGenericScheduler(t *testing.T) {
 	}
 }
 
+func TestSchedulingErrorClassification(t *testing.T) {
+	prioritizers := []algorithm.PriorityConfig{{Map: EqualPriorityMap, Weight: 1}}
+
+	// An empty cluster yields ErrNoNodesAvailable.
+	cache := schedulercache.New(time.Duration(0), wait.NeverStop)
+	scheduler := NewGenericScheduler(
+		cache, map[string]algorithm.FitPredicate{"true": truePredicate}, algorithm.EmptyMetadataProducer,
+		prioritizers, algorithm.EmptyMetadataProducer, []algorithm.SchedulerExtender{})
+	_, err := scheduler.Schedule(&v1.Pod{}, algorithm.FakeNodeLister(makeNodeList(nil)))
+	if !IsNoNodesAvailable(err) {
+		t.Errorf("expected IsNoNodesAvailable for %v", err)
+	}
+	if _, ok := IsFitError(err); ok {
+		t.Errorf("an empty cluster is not a fit error: %v", err)
+	}
+
+	// Nodes exist but none of them fit: a FitError.
+	cache.AddNode(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "machine1"}})
+	scheduler = NewGenericScheduler(
+		cache, map[string]algorithm.FitPredicate{"false": falsePredicate}, algorithm.EmptyMetadataProducer,
+		prioritizers, algorithm.EmptyMetadataProducer, []algorithm.SchedulerExtender{})
+	_, err = scheduler.Schedule(&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod"}}, algorithm.FakeNodeLister(makeNodeList([]string{"machine1"})))
+	fitError, ok := IsFitError(err)
+	if !ok {
+		t.Fatalf("expected IsFitError for %v", err)
+	}
+	if fitError.NumAllNodes != 1 || fitError.NumFailedNodes != 1 {
+		t.Errorf("unexpected fit error detail: %+v", fitError)
+	}
+	if IsNoNodesAvailable(err) {
+		t.Errorf("a fit error is not ErrNoNodesAvailable: %v", err)
+	}
+
+	// Other errors match neither helper.
+	other := fmt.Errorf("transient cache failure")
+	if IsNoNodesAvailable(other) {
+		t.Errorf("expected false for %v", other)
+	}
+	if _, ok := IsFitError(other); ok {
+		t.Errorf("expected false for %v", other)
+	}
+}
+
 func TestFindFitAllError(t *testing.T) {
 	nodes := []string{"3", "2", "1"}
 	predicates := map[string]algorithm.FitPredicate{"true": truePredicate, "false": falsePredicate}
@@ -326,7 +476,7 @@ func TestFindFitAllError(t *testing.T) {
 		"2": schedulercache.NewNodeInfo(),
 		"1": schedulercache.NewNodeInfo(),
 	}
-	_, predicateMap, err := findNodesThatFit(&v1.Pod{}, nodeNameToInfo, makeNodeList(nodes), predicates, nil, algorithm.EmptyMetadataProducer)
+	_, predicateMap, _, err := findNodesThatFit(context.Background(), &v1.Pod{}, nodeNameToInfo, makeNodeList(nodes), predicates, nil, nil, nil, algorithm.EmptyMetadataProducer, nil)
 
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
@@ -360,7 +510,7 @@ func TestFindFitSomeError(t *testing.T) {
 		nodeNameToInfo[name].SetNode(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}})
 	}
 
-	_, predicateMap, err := findNodesThatFit(pod, nodeNameToInfo, makeNodeList(nodes), predicates, nil, algorithm.EmptyMetadataProducer)
+	_, predicateMap, _, err := findNodesThatFit(context.Background(), pod, nodeNameToInfo, makeNodeList(nodes), predicates, nil, nil, nil, algorithm.EmptyMetadataProducer, nil)
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
@@ -383,6 +533,901 @@ func TestFindFitSomeError(t *testing.T) {
 	}
 }
 
+func failingPriority(pod *v1.Pod, nodeNameToInfo map[string]*schedulercache.NodeInfo, nodes []*v1.Node) (schedulerapi.HostPriorityList, error) {
+	return nil, fmt.Errorf("priority failed")
+}
+
+func failingPriorityMap(pod *v1.Pod, meta interface{}, nodeInfo *schedulercache.NodeInfo) (schedulerapi.HostPriority, error) {
+	return schedulerapi.HostPriority{}, fmt.Errorf("priority map failed")
+}
+
+func TestPrioritizeNodesErrorPropagation(t *testing.T) {
+	nodes := []string{"machine1", "machine2"}
+	nodeNameToInfo := schedulercache.CreateNodeNameToInfoMap(nil, makeNodeList(nodes))
+
+	tests := []struct {
+		priorityConfigs []algorithm.PriorityConfig
+		test            string
+	}{
+		{
+			priorityConfigs: []algorithm.PriorityConfig{
+				{Function: failingPriority, Weight: 1},
+				{Map: EqualPriorityMap, Weight: 1},
+			},
+			test: "failing priority function",
+		},
+		{
+			priorityConfigs: []algorithm.PriorityConfig{
+				{Map: failingPriorityMap, Weight: 1},
+			},
+			test: "failing priority map",
+		},
+	}
+
+	for _, test := range tests {
+		if _, err := PrioritizeNodes(context.Background(), &v1.Pod{}, nodeNameToInfo, nil,
+			test.priorityConfigs, makeNodeList(nodes), nil, nil, 0, nil, 0, nil); err == nil {
+			t.Errorf("%s: expected error to propagate", test.test)
+		}
+	}
+}
+
+func TestScheduleWithCancelledContext(t *testing.T) {
+	nodes := []string{"machine1", "machine2"}
+	cache := schedulercache.New(time.Duration(0), wait.NeverStop)
+	for _, name := range nodes {
+		cache.AddNode(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+	scheduler := NewGenericScheduler(
+		cache, map[string]algorithm.FitPredicate{"true": truePredicate},
+		algorithm.EmptyMetadataProducer, []algorithm.PriorityConfig{{Map: EqualPriorityMap, Weight: 1}},
+		algorithm.EmptyMetadataProducer, []algorithm.SchedulerExtender{}).(*genericScheduler)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "ignore"}}
+	if _, err := scheduler.ScheduleWithContext(ctx, pod, algorithm.FakeNodeLister(makeNodeList(nodes))); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+
+	// the old entry point still schedules without a context
+	if _, err := scheduler.Schedule(pod, algorithm.FakeNodeLister(makeNodeList(nodes))); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestFailedPredicateMapReasonSets(t *testing.T) {
+	failedPredicateMap := FailedPredicateMap{
+		"machine1": []algorithm.PredicateFailureReason{
+			algorithmpredicates.ErrFakePredicate,
+			algorithmpredicates.NewInsufficientResourceError(v1.ResourceCPU, 4000, 0, 2000),
+		},
+		"machine2": []algorithm.PredicateFailureReason{algorithmpredicates.ErrFakePredicate},
+	}
+
+	reasonSets := failedPredicateMap.ReasonSets()
+	expected := map[string]sets.String{
+		"machine1": sets.NewString("FakePredicateError", "Insufficient cpu"),
+		"machine2": sets.NewString("FakePredicateError"),
+	}
+	if !reflect.DeepEqual(expected, reasonSets) {
+		t.Errorf("expected %v, got %v", expected, reasonSets)
+	}
+}
+
+func TestExcludeNodes(t *testing.T) {
+	nodes := []string{"3", "2", "1"}
+	predicates := map[string]algorithm.FitPredicate{"true": truePredicate}
+	nodeNameToInfo := map[string]*schedulercache.NodeInfo{
+		"3": schedulercache.NewNodeInfo(),
+		"2": schedulercache.NewNodeInfo(),
+		"1": schedulercache.NewNodeInfo(),
+	}
+	for name := range nodeNameToInfo {
+		nodeNameToInfo[name].SetNode(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+
+	fitNodes, predicateMap, _, err := findNodesThatFit(context.Background(), &v1.Pod{}, nodeNameToInfo, makeNodeList(nodes), predicates, nil, nil, nil, algorithm.EmptyMetadataProducer, sets.NewString("2"))
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	for _, node := range fitNodes {
+		if node.Name == "2" {
+			t.Errorf("excluded node appeared in the feasible set: %v", fitNodes)
+		}
+	}
+	if len(fitNodes) != len(nodes)-1 {
+		t.Errorf("unexpected feasible set: %v", fitNodes)
+	}
+
+	failures, found := predicateMap["2"]
+	if !found {
+		t.Errorf("failed to find excluded node in %v", predicateMap)
+	}
+	if len(failures) != 1 || failures[0] != algorithmpredicates.ErrNodeExcluded {
+		t.Errorf("unexpected failures: %v", failures)
+	}
+}
+
+func TestApplyQoSWeights(t *testing.T) {
+	nodes := []string{"2", "1"}
+	nodeNameToInfo := schedulercache.CreateNodeNameToInfoMap(nil, makeNodeList(nodes))
+	priorityConfigs := []algorithm.PriorityConfig{
+		{Name: "Numeric", Function: numericPriority, Weight: 1},
+		{Name: "Reverse", Function: reverseNumericPriority, Weight: 1},
+	}
+	doubleNumeric := func(pod *v1.Pod) map[string]int {
+		return map[string]int{"Numeric": 2}
+	}
+
+	adjusted := applyQoSWeights(&v1.Pod{}, priorityConfigs, doubleNumeric)
+	if adjusted[0].Weight != 2 || adjusted[1].Weight != 1 {
+		t.Errorf("unexpected adjusted weights: %v, %v", adjusted[0].Weight, adjusted[1].Weight)
+	}
+	if priorityConfigs[0].Weight != 1 {
+		t.Errorf("applyQoSWeights mutated its input: %v", priorityConfigs[0].Weight)
+	}
+	if got := applyQoSWeights(&v1.Pod{}, priorityConfigs, nil); !reflect.DeepEqual(got, priorityConfigs) {
+		t.Errorf("nil hook changed the configs")
+	}
+
+	// with equal weights the numeric and reverse priorities cancel out; with
+	// the numeric weight doubled the higher-numbered node must win
+	scores, err := PrioritizeNodes(context.Background(), &v1.Pod{}, nodeNameToInfo, nil, adjusted, makeNodeList(nodes), nil, nil, 0, nil, 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	byHost := map[string]int{}
+	for _, hostPriority := range scores {
+		byHost[hostPriority.Host] = hostPriority.Score
+	}
+	if byHost["2"] <= byHost["1"] {
+		t.Errorf("expected node 2 to outscore node 1 with doubled numeric weight, got %v", byHost)
+	}
+}
+
+func TestSoftPredicates(t *testing.T) {
+	nodes := []string{"machine1", "machine2"}
+	nodeNameToInfo := schedulercache.CreateNodeNameToInfoMap(nil, makeNodeList(nodes))
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "machine1"}}
+
+	// A failed soft predicate keeps the node feasible and records a failure.
+	fitNodes, predicateMap, softFailures, err := findNodesThatFit(context.Background(), pod, nodeNameToInfo, makeNodeList(nodes),
+		map[string]algorithm.FitPredicate{"true": truePredicate}, nil,
+		map[string]algorithm.FitPredicate{"matches": matchesPredicate},
+		nil, algorithm.EmptyMetadataProducer, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fitNodes) != len(nodes) {
+		t.Fatalf("expected both nodes to stay feasible, got %v", fitNodes)
+	}
+	if len(predicateMap) != 0 {
+		t.Errorf("soft predicate failures leaked into the failed predicate map: %v", predicateMap)
+	}
+	if softFailures["machine2"] != 1 || softFailures["machine1"] != 0 {
+		t.Errorf("unexpected soft failures: %v", softFailures)
+	}
+
+	// The failure lowers the node's rank via a score penalty.
+	cache := schedulercache.New(time.Duration(0), wait.NeverStop)
+	for _, name := range nodes {
+		cache.AddNode(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+	scheduler := NewGenericScheduler(
+		cache,
+		map[string]algorithm.FitPredicate{"true": truePredicate},
+		algorithm.EmptyMetadataProducer,
+		[]algorithm.PriorityConfig{{Map: EqualPriorityMap, Weight: 1}},
+		algorithm.EmptyMetadataProducer,
+		[]algorithm.SchedulerExtender{}).(*genericScheduler)
+	scheduler.SetSoftPredicates(map[string]algorithm.FitPredicate{"matches": matchesPredicate}, 2)
+
+	explanation, err := scheduler.Explain(pod, algorithm.FakeNodeLister(makeNodeList(nodes)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(explanation.FeasibleNodes) != len(nodes) {
+		t.Errorf("expected both nodes to stay feasible, got %v", explanation.FeasibleNodes)
+	}
+	byHost := map[string]int{}
+	for _, hostPriority := range explanation.Scores {
+		byHost[hostPriority.Host] = hostPriority.Score
+	}
+	if byHost["machine2"] != byHost["machine1"]-2 {
+		t.Errorf("expected machine2 to be penalized by 2, got %v", byHost)
+	}
+	if explanation.SuggestedHost != "machine1" {
+		t.Errorf("expected machine1 to win, got %s", explanation.SuggestedHost)
+	}
+}
+
+// misbehavingExtender passes every candidate but pads its answer with the
+// first node repeated and a node that was never a candidate.
+type misbehavingExtender struct{}
+
+func (misbehavingExtender) Filter(pod *v1.Pod, nodes []*v1.Node) ([]*v1.Node, schedulerapi.FailedNodesMap, error) {
+	returned := append([]*v1.Node{}, nodes...)
+	if len(nodes) > 0 {
+		returned = append(returned, nodes[0])
+	}
+	returned = append(returned, &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "not-a-candidate"}})
+	return returned, schedulerapi.FailedNodesMap{}, nil
+}
+
+func (misbehavingExtender) IsFilterLenient() bool { return false }
+
+func (misbehavingExtender) Prioritize(pod *v1.Pod, nodes []*v1.Node) (*schedulerapi.HostPriorityList, int, error) {
+	return &schedulerapi.HostPriorityList{}, 0, nil
+}
+
+func (misbehavingExtender) IsHardError() bool { return true }
+
+func (misbehavingExtender) IsBinder() bool { return false }
+
+func (misbehavingExtender) Bind(binding *v1.Binding) error { return nil }
+
+func TestExtenderNodesSanitized(t *testing.T) {
+	nodes := []string{"machine1", "machine2"}
+	nodeNameToInfo := schedulercache.CreateNodeNameToInfoMap(nil, makeNodeList(nodes))
+
+	fitNodes, _, _, err := findNodesThatFit(context.Background(), &v1.Pod{}, nodeNameToInfo, makeNodeList(nodes),
+		map[string]algorithm.FitPredicate{"true": truePredicate}, nil, nil,
+		[]algorithm.SchedulerExtender{misbehavingExtender{}}, algorithm.EmptyMetadataProducer, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(fitNodes) != len(nodes) {
+		t.Fatalf("expected duplicates and unknown nodes to be dropped, got %v", fitNodes)
+	}
+	seen := map[string]bool{}
+	for _, node := range fitNodes {
+		if seen[node.Name] {
+			t.Errorf("node %v appeared more than once in the feasible set", node.Name)
+		}
+		seen[node.Name] = true
+		if node.Name == "not-a-candidate" {
+			t.Errorf("non-candidate node leaked into the feasible set")
+		}
+	}
+}
+
+func TestPredicateOrderShortCircuit(t *testing.T) {
+	var invoked []string
+	recordingPredicate := func(name string, fits bool) algorithm.FitPredicate {
+		return func(pod *v1.Pod, meta interface{}, nodeInfo *schedulercache.NodeInfo) (bool, []algorithm.PredicateFailureReason, error) {
+			invoked = append(invoked, name)
+			if fits {
+				return true, nil, nil
+			}
+			return false, []algorithm.PredicateFailureReason{algorithmpredicates.ErrFakePredicate}, nil
+		}
+	}
+	// a single node keeps the predicate goroutines serial so recording the
+	// invocations needs no locking
+	nodes := []string{"machine1"}
+	nodeNameToInfo := schedulercache.CreateNodeNameToInfoMap(nil, makeNodeList(nodes))
+	predicates := map[string]algorithm.FitPredicate{
+		"cheap":     recordingPredicate("cheap", false),
+		"expensive": recordingPredicate("expensive", true),
+	}
+
+	// with an order, the cheap failure shields the expensive predicate
+	_, predicateMap, _, err := findNodesThatFit(context.Background(), &v1.Pod{}, nodeNameToInfo, makeNodeList(nodes),
+		predicates, []string{"cheap", "expensive"}, nil, nil, algorithm.EmptyMetadataProducer, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(invoked) != 1 || invoked[0] != "cheap" {
+		t.Errorf("expected only the cheap predicate to run, got %v", invoked)
+	}
+	if len(predicateMap["machine1"]) != 1 {
+		t.Errorf("expected a single failure reason, got %v", predicateMap["machine1"])
+	}
+
+	// without an order every predicate runs
+	invoked = nil
+	if _, _, _, err := findNodesThatFit(context.Background(), &v1.Pod{}, nodeNameToInfo, makeNodeList(nodes),
+		predicates, nil, nil, nil, algorithm.EmptyMetadataProducer, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(invoked) != 2 {
+		t.Errorf("expected both predicates to run without an order, got %v", invoked)
+	}
+
+	// the order is honored end to end when configured on the scheduler
+	invoked = nil
+	cache := schedulercache.New(time.Duration(0), wait.NeverStop)
+	for _, name := range nodes {
+		cache.AddNode(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+	scheduler := NewGenericScheduler(
+		cache,
+		predicates,
+		algorithm.EmptyMetadataProducer,
+		[]algorithm.PriorityConfig{{Map: EqualPriorityMap, Weight: 1}},
+		algorithm.EmptyMetadataProducer,
+		[]algorithm.SchedulerExtender{}).(*genericScheduler)
+	scheduler.SetPredicateOrder([]string{"cheap", "expensive"})
+	if _, err := scheduler.Schedule(&v1.Pod{}, algorithm.FakeNodeLister(makeNodeList(nodes))); err == nil {
+		t.Errorf("expected a fit error")
+	}
+	if len(invoked) != 1 || invoked[0] != "cheap" {
+		t.Errorf("expected only the cheap predicate to run, got %v", invoked)
+	}
+}
+
+func TestSchedulingHooks(t *testing.T) {
+	nodes := []string{"machine1", "machine2"}
+	cache := schedulercache.New(time.Duration(0), wait.NeverStop)
+	for _, name := range nodes {
+		cache.AddNode(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+	scheduler := NewGenericScheduler(
+		cache,
+		map[string]algorithm.FitPredicate{"matches": matchesPredicate},
+		algorithm.EmptyMetadataProducer,
+		[]algorithm.PriorityConfig{{Map: EqualPriorityMap, Weight: 1}},
+		algorithm.EmptyMetadataProducer,
+		[]algorithm.SchedulerExtender{}).(*genericScheduler)
+
+	var numFeasible, numFailed, numScored int
+	var winner string
+	scheduler.SetSchedulingHooks(SchedulingHooks{
+		AfterFiltering: func(feasible, failed int) {
+			numFeasible, numFailed = feasible, failed
+		},
+		AfterPrioritizing: func(scores schedulerapi.HostPriorityList) {
+			numScored = len(scores)
+		},
+		AfterHostSelection: func(host string) {
+			winner = host
+		},
+	})
+
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "machine1"}}
+	machine, err := scheduler.Schedule(pod, algorithm.FakeNodeLister(makeNodeList(nodes)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if numFeasible != 1 || numFailed != 1 {
+		t.Errorf("expected 1 feasible and 1 failed node, got %d and %d", numFeasible, numFailed)
+	}
+	if numScored != 1 {
+		t.Errorf("expected 1 scored node, got %d", numScored)
+	}
+	if winner != machine {
+		t.Errorf("expected winner %s to match the scheduled host %s", winner, machine)
+	}
+
+	// nil hooks are skipped without panicking
+	scheduler.SetSchedulingHooks(SchedulingHooks{})
+	if _, err := scheduler.Schedule(pod, algorithm.FakeNodeLister(makeNodeList(nodes))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestNormalizePriorityConfigs(t *testing.T) {
+	nodes := []string{"machine1", "machine2"}
+	nodeNameToInfo := schedulercache.CreateNodeNameToInfoMap(nil, makeNodeList(nodes))
+	hundredPriority := func(pod *v1.Pod, nodeNameToInfo map[string]*schedulercache.NodeInfo, nodes []*v1.Node) (schedulerapi.HostPriorityList, error) {
+		result := schedulerapi.HostPriorityList{}
+		for _, node := range nodes {
+			result = append(result, schedulerapi.HostPriority{Host: node.Name, Score: 100})
+		}
+		return result, nil
+	}
+	priorityConfigs := []algorithm.PriorityConfig{
+		{Name: "Hundred", Function: hundredPriority, Weight: 2},
+	}
+
+	// without normalization the out-of-range scores pass through
+	scores, err := PrioritizeNodes(context.Background(), &v1.Pod{}, nodeNameToInfo, nil,
+		priorityConfigs, makeNodeList(nodes), nil, nil, 0, nil, 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, hostPriority := range scores {
+		if hostPriority.Score != 200 {
+			t.Errorf("expected raw weighted score 200 for %s, got %d", hostPriority.Host, hostPriority.Score)
+		}
+	}
+
+	// with normalization each function's contribution is clamped to 10 before weighting
+	scores, err = PrioritizeNodes(context.Background(), &v1.Pod{}, nodeNameToInfo, nil,
+		normalizePriorityConfigs(priorityConfigs), makeNodeList(nodes), nil, nil, 0, nil, 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, hostPriority := range scores {
+		if hostPriority.Score != 2*maxPriorityScore {
+			t.Errorf("expected clamped weighted score %d for %s, got %d", 2*maxPriorityScore, hostPriority.Host, hostPriority.Score)
+		}
+	}
+
+	// in-range scores are untouched
+	clamped := clampScore("Hundred", "machine1", 7)
+	if clamped != 7 {
+		t.Errorf("expected in-range score to pass through, got %d", clamped)
+	}
+	if clamped := clampScore("Hundred", "machine1", -3); clamped != 0 {
+		t.Errorf("expected negative score to clamp to 0, got %d", clamped)
+	}
+}
+
+func TestPrioritizeNodesConcurrencyLimit(t *testing.T) {
+	nodes := []string{"3", "2", "1"}
+	nodeNameToInfo := schedulercache.CreateNodeNameToInfoMap(nil, makeNodeList(nodes))
+	priorityConfigs := []algorithm.PriorityConfig{
+		{Name: "Numeric", Function: numericPriority, Weight: 2},
+		{Name: "Reverse", Function: reverseNumericPriority, Weight: 1},
+		{Name: "Equal", Map: EqualPriorityMap, Weight: 1},
+	}
+
+	// the limit only bounds concurrency; the scores must be identical whether
+	// the priority functions run one at a time or all at once
+	var baseline schedulerapi.HostPriorityList
+	for _, limit := range []int{1, 2, 0, 100} {
+		scores, err := PrioritizeNodes(context.Background(), &v1.Pod{}, nodeNameToInfo, nil,
+			priorityConfigs, makeNodeList(nodes), nil, nil, 0, nil, limit, nil)
+		if err != nil {
+			t.Fatalf("limit %d: unexpected error: %v", limit, err)
+		}
+		if baseline == nil {
+			baseline = scores
+			continue
+		}
+		if !reflect.DeepEqual(baseline, scores) {
+			t.Errorf("limit %d: expected %v, got %v", limit, baseline, scores)
+		}
+	}
+}
+
+func benchmarkPrioritizeNodes(b *testing.B, concurrency int) {
+	nodes := []string{"3", "2", "1"}
+	nodeNameToInfo := schedulercache.CreateNodeNameToInfoMap(nil, makeNodeList(nodes))
+	priorityConfigs := []algorithm.PriorityConfig{
+		{Name: "Numeric", Function: numericPriority, Weight: 2},
+		{Name: "Reverse", Function: reverseNumericPriority, Weight: 1},
+		{Name: "Equal", Map: EqualPriorityMap, Weight: 1},
+	}
+	for i := 0; i < b.N; i++ {
+		if _, err := PrioritizeNodes(context.Background(), &v1.Pod{}, nodeNameToInfo, nil,
+			priorityConfigs, makeNodeList(nodes), nil, nil, 0, nil, concurrency, nil); err != nil {
+			b.Fatalf("unexpected error: %v", err)
+		}
+	}
+}
+
+func BenchmarkPrioritizeNodesSerial(b *testing.B) {
+	benchmarkPrioritizeNodes(b, 1)
+}
+
+func BenchmarkPrioritizeNodesUnbounded(b *testing.B) {
+	benchmarkPrioritizeNodes(b, 100)
+}
+
+func TestScoreBreakdown(t *testing.T) {
+	nodes := []string{"2", "1"}
+	nodeNameToInfo := schedulercache.CreateNodeNameToInfoMap(nil, makeNodeList(nodes))
+	priorityConfigs := []algorithm.PriorityConfig{
+		{Name: "Numeric", Function: numericPriority, Weight: 2},
+		{Map: EqualPriorityMap, Weight: 3},
+	}
+	extender := &FakeExtender{
+		prioritizers: []priorityConfig{{machine2PrioritizerExtender, 10}},
+		weight:       1,
+	}
+	penalties := map[string]int{"1": 4}
+
+	breakdown := ScoreBreakdown{}
+	scores, err := PrioritizeNodes(context.Background(), &v1.Pod{}, nodeNameToInfo, nil,
+		priorityConfigs, makeNodeList(nodes), []algorithm.SchedulerExtender{extender}, nil, 0,
+		penalties, 0, breakdown)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// every contributor shows up under its name and the weighted shares sum
+	// to the final combined score
+	for _, hostPriority := range scores {
+		contributions := breakdown[hostPriority.Host]
+		if _, ok := contributions["Numeric"]; !ok {
+			t.Errorf("missing Numeric entry for %s: %v", hostPriority.Host, contributions)
+		}
+		if _, ok := contributions["Priority[1]"]; !ok {
+			t.Errorf("missing entry for the unnamed priority on %s: %v", hostPriority.Host, contributions)
+		}
+		if _, ok := contributions["Extender[0]"]; !ok {
+			t.Errorf("missing extender entry for %s: %v", hostPriority.Host, contributions)
+		}
+		sum := 0
+		for _, contribution := range contributions {
+			sum += contribution.Weighted
+		}
+		if sum != hostPriority.Score {
+			t.Errorf("breakdown for %s sums to %d, final score is %d: %v", hostPriority.Host, sum, hostPriority.Score, contributions)
+		}
+	}
+	if penalty, ok := breakdown["1"]["Penalty"]; !ok || penalty.Weighted != -4 {
+		t.Errorf("expected a -4 penalty entry for node 1, got %v", breakdown["1"])
+	}
+	if _, ok := breakdown["2"]["Penalty"]; ok {
+		t.Errorf("unexpected penalty entry for node 2: %v", breakdown["2"])
+	}
+
+	// a nil breakdown records nothing and changes no scores
+	unrecorded, err := PrioritizeNodes(context.Background(), &v1.Pod{}, nodeNameToInfo, nil,
+		priorityConfigs, makeNodeList(nodes), []algorithm.SchedulerExtender{extender}, nil, 0,
+		penalties, 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(scores, unrecorded) {
+		t.Errorf("recording the breakdown changed the scores: %v vs %v", scores, unrecorded)
+	}
+}
+
+// TestExplicitEqualPriority combines an explicitly listed EqualPriority with
+// another priority. The fallback that applies EqualPriority when no configs
+// exist must not fire on top of the explicit entry, so each node's score is
+// exactly its numeric score plus the weighted equal share.
+func TestExplicitEqualPriority(t *testing.T) {
+	nodes := []string{"1", "3"}
+	nodeNameToInfo := schedulercache.CreateNodeNameToInfoMap(nil, makeNodeList(nodes))
+	priorityConfigs := []algorithm.PriorityConfig{
+		{Name: "EqualPriority", Map: EqualPriorityMap, Weight: 2},
+		{Name: "Numeric", Function: numericPriority, Weight: 1},
+	}
+
+	scores, err := PrioritizeNodes(context.Background(), &v1.Pod{}, nodeNameToInfo, nil,
+		priorityConfigs, makeNodeList(nodes), nil, nil, 0, nil, 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]int{"1": 1*2 + 1, "3": 1*2 + 3}
+	for _, hostPriority := range scores {
+		if hostPriority.Score != expected[hostPriority.Host] {
+			t.Errorf("unexpected score for %s: got %d, want %d", hostPriority.Host, hostPriority.Score, expected[hostPriority.Host])
+		}
+	}
+}
+
+func TestExplainScores(t *testing.T) {
+	nodes := []string{"machine1", "machine2"}
+	cache := schedulercache.New(time.Duration(0), wait.NeverStop)
+	for _, name := range nodes {
+		cache.AddNode(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+	scheduler := NewGenericScheduler(
+		cache,
+		map[string]algorithm.FitPredicate{"true": truePredicate},
+		algorithm.EmptyMetadataProducer,
+		[]algorithm.PriorityConfig{{Name: "Equal", Map: EqualPriorityMap, Weight: 2}},
+		algorithm.EmptyMetadataProducer,
+		[]algorithm.SchedulerExtender{}).(*genericScheduler)
+
+	explanation, breakdown, err := scheduler.ExplainScores(&v1.Pod{}, algorithm.FakeNodeLister(makeNodeList(nodes)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, hostPriority := range explanation.Scores {
+		sum := 0
+		for _, contribution := range breakdown[hostPriority.Host] {
+			sum += contribution.Weighted
+		}
+		if sum != hostPriority.Score {
+			t.Errorf("breakdown for %s sums to %d, final score is %d", hostPriority.Host, sum, hostPriority.Score)
+		}
+		if entry := breakdown[hostPriority.Host]["Equal"]; entry.Raw != 1 || entry.Weighted != 2 {
+			t.Errorf("unexpected Equal entry for %s: %v", hostPriority.Host, entry)
+		}
+	}
+}
+
+type nodeRecord struct {
+	feasible bool
+	reasons  []string
+	score    int
+}
+
+type recordingTracer struct {
+	records map[string][]nodeRecord
+}
+
+func (r *recordingTracer) RecordNode(nodeName string, feasible bool, reasons []string, score int) {
+	if r.records == nil {
+		r.records = make(map[string][]nodeRecord)
+	}
+	r.records[nodeName] = append(r.records[nodeName], nodeRecord{feasible: feasible, reasons: reasons, score: score})
+}
+
+func TestSchedulerTracer(t *testing.T) {
+	nodes := []string{"3", "2", "1"}
+	cache := schedulercache.New(time.Duration(0), wait.NeverStop)
+	for _, name := range nodes {
+		cache.AddNode(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+	tracer := &recordingTracer{}
+	scheduler := NewGenericScheduler(
+		cache, map[string]algorithm.FitPredicate{"matches": matchesPredicate},
+		algorithm.EmptyMetadataProducer, []algorithm.PriorityConfig{{Map: EqualPriorityMap, Weight: 1}},
+		algorithm.EmptyMetadataProducer, []algorithm.SchedulerExtender{}).(*genericScheduler)
+	scheduler.SetTracer(tracer)
+
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "2"}}
+	if _, err := scheduler.Schedule(pod, algorithm.FakeNodeLister(makeNodeList(nodes))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, name := range nodes {
+		records := tracer.records[name]
+		if len(records) != 1 {
+			t.Errorf("node %s: expected exactly one record, got %d", name, len(records))
+			continue
+		}
+		record := records[0]
+		if name == "2" {
+			if !record.feasible || record.score != 1 {
+				t.Errorf("node %s: expected feasible record with score 1, got %#v", name, record)
+			}
+		} else {
+			if record.feasible || len(record.reasons) == 0 {
+				t.Errorf("node %s: expected infeasible record with reasons, got %#v", name, record)
+			}
+		}
+	}
+}
+
+func TestExplain(t *testing.T) {
+	nodes := []string{"3", "2", "1"}
+	cache := schedulercache.New(time.Duration(0), wait.NeverStop)
+	for _, name := range nodes {
+		cache.AddNode(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+	scheduler := NewGenericScheduler(
+		cache, map[string]algorithm.FitPredicate{"matches": matchesPredicate},
+		algorithm.EmptyMetadataProducer, []algorithm.PriorityConfig{{Function: numericPriority, Weight: 1}},
+		algorithm.EmptyMetadataProducer, []algorithm.SchedulerExtender{}).(*genericScheduler)
+
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "2"}}
+	explanation, err := scheduler.Explain(pod, algorithm.FakeNodeLister(makeNodeList(nodes)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if explanation.SuggestedHost != "2" {
+		t.Errorf("expected suggested host 2, got %q", explanation.SuggestedHost)
+	}
+	if len(explanation.FeasibleNodes) != 1 || explanation.FeasibleNodes[0] != "2" {
+		t.Errorf("unexpected feasible nodes: %v", explanation.FeasibleNodes)
+	}
+	if _, failed := explanation.FailedPredicates[explanation.SuggestedHost]; failed {
+		t.Errorf("suggested host must not carry predicate failures: %v", explanation.FailedPredicates)
+	}
+	topScore := 0
+	for _, hostPriority := range explanation.Scores {
+		if hostPriority.Score > topScore {
+			topScore = hostPriority.Score
+		}
+	}
+	for _, hostPriority := range explanation.Scores {
+		if hostPriority.Host == explanation.SuggestedHost && hostPriority.Score != topScore {
+			t.Errorf("suggested host does not have the top score: %v", explanation.Scores)
+		}
+	}
+	for _, name := range []string{"1", "3"} {
+		if _, failed := explanation.FailedPredicates[name]; !failed {
+			t.Errorf("expected predicate failures for node %s: %v", name, explanation.FailedPredicates)
+		}
+	}
+
+	// a pod with no feasible nodes still yields an explanation
+	unschedulable := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "nowhere"}}
+	explanation, err = scheduler.Explain(unschedulable, algorithm.FakeNodeLister(makeNodeList(nodes)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if explanation.SuggestedHost != "" || len(explanation.FeasibleNodes) != 0 {
+		t.Errorf("expected an empty result for an unschedulable pod, got %#v", explanation)
+	}
+	if len(explanation.FailedPredicates) != len(nodes) {
+		t.Errorf("expected failures for every node: %v", explanation.FailedPredicates)
+	}
+}
+
+func TestPreview(t *testing.T) {
+	nodes := []string{"3", "2", "1"}
+	cache := schedulercache.New(time.Duration(0), wait.NeverStop)
+	for _, name := range nodes {
+		cache.AddNode(&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}})
+	}
+	scheduler := NewGenericScheduler(
+		cache, map[string]algorithm.FitPredicate{"true": truePredicate},
+		algorithm.EmptyMetadataProducer, []algorithm.PriorityConfig{{Function: numericPriority, Weight: 1}},
+		algorithm.EmptyMetadataProducer, []algorithm.SchedulerExtender{}, LowestNameTieBreaker).(*genericScheduler)
+
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "ignore"}}
+	ranked, failed, err := scheduler.Preview(pod, algorithm.FakeNodeLister(makeNodeList(nodes)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ranked) != len(nodes) {
+		t.Fatalf("expected a score for every node, got %v", ranked)
+	}
+	if len(failed) != 0 {
+		t.Errorf("unexpected predicate failures: %v", failed)
+	}
+	for i := 1; i < len(ranked); i++ {
+		if ranked[i].Score > ranked[i-1].Score {
+			t.Errorf("list is not ranked from highest to lowest: %v", ranked)
+		}
+	}
+
+	machine, err := scheduler.Schedule(pod, algorithm.FakeNodeLister(makeNodeList(nodes)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ranked[0].Host != machine {
+		t.Errorf("expected Preview's top host %q to match Schedule's pick %q", ranked[0].Host, machine)
+	}
+
+	// infeasible nodes are reported rather than silently dropped
+	picky := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "2"}}
+	pickyScheduler := NewGenericScheduler(
+		cache, map[string]algorithm.FitPredicate{"matches": matchesPredicate},
+		algorithm.EmptyMetadataProducer, []algorithm.PriorityConfig{{Function: numericPriority, Weight: 1}},
+		algorithm.EmptyMetadataProducer, []algorithm.SchedulerExtender{}, LowestNameTieBreaker).(*genericScheduler)
+	ranked, failed, err = pickyScheduler.Preview(picky, algorithm.FakeNodeLister(makeNodeList(nodes)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ranked) != 1 || ranked[0].Host != "2" {
+		t.Errorf("unexpected ranked list: %v", ranked)
+	}
+	for _, name := range []string{"1", "3"} {
+		if _, ok := failed[name]; !ok {
+			t.Errorf("expected predicate failures for node %s: %v", name, failed)
+		}
+	}
+}
+
+func TestCapFeasibleNodes(t *testing.T) {
+	nodes := makeNodeList([]string{"machine1", "machine2", "machine3", "machine4", "machine5"})
+	g := &genericScheduler{}
+
+	// without a cap the full list is returned
+	if got := g.capFeasibleNodes(nodes); len(got) != len(nodes) {
+		t.Errorf("expected %d nodes without a cap, got %d", len(nodes), len(got))
+	}
+
+	g.SetMaxFeasibleNodesToScore(2)
+	first := g.capFeasibleNodes(nodes)
+	if len(first) != 2 {
+		t.Fatalf("expected 2 nodes, got %d", len(first))
+	}
+	if first[0].Name != "machine1" || first[1].Name != "machine2" {
+		t.Errorf("unexpected first window: %v, %v", first[0].Name, first[1].Name)
+	}
+
+	// the window rotates on the next scheduling cycle
+	second := g.capFeasibleNodes(nodes)
+	if len(second) != 2 {
+		t.Fatalf("expected 2 nodes, got %d", len(second))
+	}
+	if second[0].Name != "machine3" || second[1].Name != "machine4" {
+		t.Errorf("unexpected second window: %v, %v", second[0].Name, second[1].Name)
+	}
+}
+
+func TestPercentageOfNodesToScore(t *testing.T) {
+	names := make([]string, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		names = append(names, fmt.Sprintf("machine%d", i))
+	}
+	nodes := makeNodeList(names)
+
+	g := &genericScheduler{}
+	g.SetPercentageOfNodesToScore(10, 50)
+	if got := g.capFeasibleNodes(nodes); len(got) != 100 {
+		t.Errorf("expected 100 nodes at 10%% of 1000, got %d", len(got))
+	}
+	// successive cycles rotate the window instead of re-scoring the same prefix
+	if next := g.capFeasibleNodes(nodes); len(next) != 100 || next[0].Name != "machine100" {
+		t.Errorf("expected the window to rotate to machine100, got %v", next[0].Name)
+	}
+	// the minimum keeps smaller clusters from being starved by the percentage
+	if got := g.capFeasibleNodes(makeNodeList(names[:100])); len(got) != 50 {
+		t.Errorf("expected the 50-node floor for 100 nodes, got %d", len(got))
+	}
+
+	// end to end a valid host is still returned and only the sample is scored
+	cache := schedulercache.New(time.Duration(0), wait.NeverStop)
+	for _, node := range nodes {
+		cache.AddNode(node)
+	}
+	scored := 0
+	counting := func(pod *v1.Pod, nodeNameToInfo map[string]*schedulercache.NodeInfo, scoredNodes []*v1.Node) (schedulerapi.HostPriorityList, error) {
+		scored = len(scoredNodes)
+		result := make(schedulerapi.HostPriorityList, 0, len(scoredNodes))
+		for _, node := range scoredNodes {
+			result = append(result, schedulerapi.HostPriority{Host: node.Name, Score: 1})
+		}
+		return result, nil
+	}
+	scheduler := NewGenericScheduler(
+		cache, map[string]algorithm.FitPredicate{"true": truePredicate},
+		algorithm.EmptyMetadataProducer, []algorithm.PriorityConfig{{Function: counting, Weight: 1}},
+		algorithm.EmptyMetadataProducer, []algorithm.SchedulerExtender{}).(*genericScheduler)
+	scheduler.SetPercentageOfNodesToScore(10, 50)
+
+	host, err := scheduler.Schedule(&v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "sampled"}}, algorithm.FakeNodeLister(nodes))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(host, "machine") {
+		t.Errorf("unexpected host: %q", host)
+	}
+	if scored != 100 {
+		t.Errorf("expected 100 nodes to be scored, got %d", scored)
+	}
+}
+
+func TestStickyNodeHint(t *testing.T) {
+	nodes := []string{"machine1", "machine2", "machine3"}
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "sticky"}}
+	stickyNode := func(pod *v1.Pod) string { return "machine2" }
+	priorityConfigs := []algorithm.PriorityConfig{{Map: EqualPriorityMap, Weight: 1}}
+
+	tests := []struct {
+		feasibleNodes []string
+		expectsWinner bool
+		test          string
+	}{
+		{
+			feasibleNodes: nodes,
+			expectsWinner: true,
+			test:          "sticky node wins ties when feasible",
+		},
+		{
+			feasibleNodes: []string{"machine1", "machine3"},
+			expectsWinner: false,
+			test:          "sticky node is ignored when infeasible",
+		},
+	}
+
+	for _, test := range tests {
+		nodeNameToInfo := schedulercache.CreateNodeNameToInfoMap(nil, makeNodeList(test.feasibleNodes))
+		list, err := PrioritizeNodes(
+			context.Background(),
+			pod, nodeNameToInfo, algorithm.EmptyMetadataProducer, priorityConfigs,
+			makeNodeList(test.feasibleNodes), []algorithm.SchedulerExtender{}, stickyNode, 5, nil, 0, nil)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.test, err)
+			continue
+		}
+		scheduler := genericScheduler{}
+		host, err := scheduler.selectHost(list)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.test, err)
+			continue
+		}
+		if test.expectsWinner && host != "machine2" {
+			t.Errorf("%s: expected machine2 to be selected, got %s", test.test, host)
+		}
+		if !test.expectsWinner {
+			for _, hp := range list {
+				if hp.Score != 1 {
+					t.Errorf("%s: expected equal scores, got list %#v", test.test, list)
+				}
+			}
+		}
+	}
+}
+
 func makeNode(node string, milliCPU, memory int64) *v1.Node {
 	return &v1.Node{
 		ObjectMeta: metav1.ObjectMeta{Name: node},
@@ -401,21 +1446,48 @@ func makeNode(node string, milliCPU, memory int64) *v1.Node {
 
 func TestHumanReadableFitError(t *testing.T) {
 	error := &FitError{
-		Pod: &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "2"}},
+		Pod:            &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "2"}},
+		NumAllNodes:    3,
+		NumFailedNodes: 3,
 		FailedPredicates: FailedPredicateMap{
 			"1": []algorithm.PredicateFailureReason{algorithmpredicates.ErrNodeUnderMemoryPressure},
 			"2": []algorithm.PredicateFailureReason{algorithmpredicates.ErrNodeUnderDiskPressure},
 			"3": []algorithm.PredicateFailureReason{algorithmpredicates.ErrNodeUnderDiskPressure},
 		},
 	}
-	if strings.Contains(error.Error(), "No nodes are available that match all of the following predicates") {
-		if strings.Contains(error.Error(), "NodeUnderDiskPressure (2)") && strings.Contains(error.Error(), "NodeUnderMemoryPressure (1)") {
-			return
+	if strings.Contains(error.Error(), "0/3 nodes are available") {
+		if strings.Contains(error.Error(), "2 NodeUnderDiskPressure") && strings.Contains(error.Error(), "1 NodeUnderMemoryPressure") {
+			// the most frequent reason is rendered first
+			if strings.Index(error.Error(), "2 NodeUnderDiskPressure") < strings.Index(error.Error(), "1 NodeUnderMemoryPressure") {
+				return
+			}
 		}
 	}
 	t.Errorf("Error message doesn't have all the information content: [" + error.Error() + "]")
 }
 
+func TestFitErrorFrequencyOrdering(t *testing.T) {
+	error := &FitError{
+		Pod:            &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "2"}},
+		NumAllNodes:    6,
+		NumFailedNodes: 6,
+		FailedPredicates: FailedPredicateMap{
+			"1": []algorithm.PredicateFailureReason{algorithmpredicates.ErrNodeUnderMemoryPressure},
+			"2": []algorithm.PredicateFailureReason{algorithmpredicates.ErrNodeSelectorNotMatch},
+			"3": []algorithm.PredicateFailureReason{algorithmpredicates.ErrNodeSelectorNotMatch},
+			"4": []algorithm.PredicateFailureReason{algorithmpredicates.ErrNodeUnderDiskPressure},
+			"5": []algorithm.PredicateFailureReason{algorithmpredicates.ErrNodeUnderDiskPressure},
+			"6": []algorithm.PredicateFailureReason{algorithmpredicates.ErrNodeUnderDiskPressure},
+		},
+	}
+	expected := "0/6 nodes are available: 3 NodeUnderDiskPressure, 2 MatchNodeSelector, 1 NodeUnderMemoryPressure."
+	for i := 0; i < 5; i++ {
+		if got := error.Error(); got != expected {
+			t.Fatalf("expected %q, got %q", expected, got)
+		}
+	}
+}
+
 // The point of this test is to show that you:
 // - get the same priority for a zero-request pod as for a pod with the defaults requests,
 //   both when the zero-request pod is already on the machine and when the zero-request pod
@@ -523,8 +1595,9 @@ func TestZeroRequest(t *testing.T) {
 		}
 		nodeNameToInfo := schedulercache.CreateNodeNameToInfoMap(test.pods, test.nodes)
 		list, err := PrioritizeNodes(
+			context.Background(),
 			test.pod, nodeNameToInfo, algorithm.EmptyMetadataProducer, priorityConfigs,
-			algorithm.FakeNodeLister(test.nodes), []algorithm.SchedulerExtender{})
+			algorithm.FakeNodeLister(test.nodes), []algorithm.SchedulerExtender{}, nil, 0, nil, 0, nil)
 		if err != nil {
 			t.Errorf("unexpected error: %v", err)
 		}
@@ -17,6 +17,7 @@ limitations under the License.
 package scheduler
 
 import (
+	"fmt"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -90,6 +91,9 @@ type Config struct {
 	NodeLister     algorithm.NodeLister
 	Algorithm      algorithm.ScheduleAlgorithm
 	Binder         Binder
+	// Extenders are consulted after host selection: an extender that claims
+	// binding (IsBinder) performs the Bind in place of the default Binder.
+	Extenders []algorithm.SchedulerExtender
 	// PodConditionUpdater is used only in case of scheduling errors. If we succeed
 	// with scheduling, PodScheduled condition will be updated in apiserver in /bind
 	// handler so that binding and setting PodCondition it is atomic.
@@ -146,6 +150,23 @@ func (s *Scheduler) Run() {
 	go wait.Until(s.scheduleOne, 0, s.config.StopEverything)
 }
 
+// bindingExtender returns the extender that has claimed binding, if any. At
+// most one extender may claim binding; more than one is a configuration error
+// that fails the bind.
+func bindingExtender(extenders []algorithm.SchedulerExtender) (algorithm.SchedulerExtender, error) {
+	var binder algorithm.SchedulerExtender
+	for _, extender := range extenders {
+		if !extender.IsBinder() {
+			continue
+		}
+		if binder != nil {
+			return nil, fmt.Errorf("only one extender may bind pods, more than one claims to")
+		}
+		binder = extender
+	}
+	return binder, nil
+}
+
 func (s *Scheduler) scheduleOne() {
 	pod := s.config.NextPod()
 	if pod.DeletionTimestamp != nil {
@@ -202,7 +223,16 @@ func (s *Scheduler) scheduleOne() {
 		bindingStart := time.Now()
 		// If binding succeeded then PodScheduled condition will be updated in apiserver so that
 		// it's atomic with setting host.
-		err := s.config.Binder.Bind(b)
+		binder, err := bindingExtender(s.config.Extenders)
+		if err == nil {
+			if binder != nil {
+				// A binding extender performs the bind itself; the default
+				// binder must not also post a binding.
+				err = binder.Bind(b)
+			} else {
+				err = s.config.Binder.Bind(b)
+			}
+		}
 		if err := s.config.SchedulerCache.FinishBinding(&assumed); err != nil {
 			glog.Errorf("scheduler cache FinishBinding failed: %v", err)
 		}
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -196,6 +197,107 @@ func TestScheduler(t *testing.T) {
 	}
 }
 
+func TestSchedulerExtenderBinding(t *testing.T) {
+	eventBroadcaster := record.NewBroadcaster()
+	eventBroadcaster.StartLogging(t.Logf).Stop()
+	testNode := v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "machine1"}}
+
+	bindingExtender := &FakeExtender{isBinder: true}
+	table := []struct {
+		extenders          []algorithm.SchedulerExtender
+		eventReason        string
+		expectExtenderBind bool
+		expectError        string
+	}{
+		// A non-binding extender leaves binding to the default binder.
+		{
+			extenders:   []algorithm.SchedulerExtender{&FakeExtender{}},
+			eventReason: "Scheduled",
+		},
+		// A binding extender performs the bind and the default binder is skipped.
+		{
+			extenders:          []algorithm.SchedulerExtender{&FakeExtender{}, bindingExtender},
+			eventReason:        "Scheduled",
+			expectExtenderBind: true,
+		},
+		// More than one binding extender is a configuration error.
+		{
+			extenders:   []algorithm.SchedulerExtender{&FakeExtender{isBinder: true}, &FakeExtender{isBinder: true}},
+			eventReason: "FailedScheduling",
+			expectError: "only one extender may bind",
+		},
+	}
+
+	for i, item := range table {
+		bindingExtender.gotBind = nil
+		var gotError error
+		var gotBinding *v1.Binding
+		c := &Config{
+			SchedulerCache: &schedulertesting.FakeCache{
+				AssumeFunc: func(pod *v1.Pod) {},
+			},
+			NodeLister: algorithm.FakeNodeLister(
+				[]*v1.Node{&testNode},
+			),
+			Algorithm: mockScheduler{testNode.Name, nil},
+			Binder: fakeBinder{func(b *v1.Binding) error {
+				gotBinding = b
+				return nil
+			}},
+			Extenders:           item.extenders,
+			PodConditionUpdater: fakePodConditionUpdater{},
+			Error: func(p *v1.Pod, err error) {
+				gotError = err
+			},
+			NextPod: func() *v1.Pod {
+				return podWithID("foo", "")
+			},
+			Recorder: eventBroadcaster.NewRecorder(api.Scheme, clientv1.EventSource{Component: "scheduler"}),
+		}
+		s := New(c)
+		called := make(chan struct{})
+		events := eventBroadcaster.StartEventWatcher(func(e *clientv1.Event) {
+			if e, a := item.eventReason, e.Reason; e != a {
+				t.Errorf("%v: expected event %v, got %v", i, e, a)
+			}
+			close(called)
+		})
+		s.scheduleOne()
+		<-called
+		events.Stop()
+
+		if item.expectError != "" {
+			if gotError == nil || !strings.Contains(gotError.Error(), item.expectError) {
+				t.Errorf("%v: expected error containing %q, got %v", i, item.expectError, gotError)
+			}
+			if gotBinding != nil {
+				t.Errorf("%v: default binder should not be called on binder conflict, bound to %v", i, gotBinding.Target.Name)
+			}
+			continue
+		}
+		if gotError != nil {
+			t.Errorf("%v: unexpected error: %v", i, gotError)
+		}
+		if item.expectExtenderBind {
+			if bindingExtender.gotBind == nil {
+				t.Errorf("%v: expected the extender to perform the bind", i)
+			} else if bindingExtender.gotBind.Target.Name != testNode.Name {
+				t.Errorf("%v: extender bound to %v, expected %v", i, bindingExtender.gotBind.Target.Name, testNode.Name)
+			}
+			if gotBinding != nil {
+				t.Errorf("%v: default binder should have been skipped, bound to %v", i, gotBinding.Target.Name)
+			}
+		} else {
+			if gotBinding == nil {
+				t.Errorf("%v: expected the default binder to perform the bind", i)
+			}
+			if bindingExtender.gotBind != nil {
+				t.Errorf("%v: extender should not have been asked to bind", i)
+			}
+		}
+	}
+}
+
 func TestSchedulerNoPhantomPodAfterExpire(t *testing.T) {
 	stop := make(chan struct{})
 	defer close(stop)
@@ -277,6 +379,8 @@ func TestSchedulerNoPhantomPodAfterDelete(t *testing.T) {
 	case err := <-errChan:
 		expectErr := &FitError{
 			Pod:              secondPod,
+			NumAllNodes:      1,
+			NumFailedNodes:   1,
 			FailedPredicates: FailedPredicateMap{node.Name: []algorithm.PredicateFailureReason{predicates.ErrPodNotFitsHostPorts}},
 		}
 		if !reflect.DeepEqual(expectErr, err) {
@@ -461,6 +565,8 @@ func TestSchedulerFailedSchedulingReasons(t *testing.T) {
 	case err := <-errChan:
 		expectErr := &FitError{
 			Pod:              podWithTooBigResourceRequests,
+			NumAllNodes:      len(nodes),
+			NumFailedNodes:   len(failedPredicatesMap),
 			FailedPredicates: failedPredicatesMap,
 		}
 		if len(fmt.Sprint(expectErr)) > 150 {
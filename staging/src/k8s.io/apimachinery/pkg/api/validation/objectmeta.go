@@ -35,7 +35,7 @@ var RepairMalformedUpdates bool = true
 
 const FieldImmutableErrorMsg string = `field is immutable`
 
-const totalAnnotationSizeLimitB int = 256 * (1 << 10) // 256 kB
+const totalAnnotationSizeLimitB int = validation.TotalAnnotationSizeLimitB
 
 // BannedOwners is a black list of object that are not allowed to be owners.
 var BannedOwners = map[schema.GroupVersionKind]struct{}{
@@ -48,14 +48,15 @@ var ValidateClusterName = NameIsDNS1035Label
 // ValidateAnnotations validates that a set of annotations are correctly defined.
 func ValidateAnnotations(annotations map[string]string, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
-	var totalSize int64
 	for k, v := range annotations {
 		for _, msg := range validation.IsQualifiedName(strings.ToLower(k)) {
 			allErrs = append(allErrs, field.Invalid(fldPath, k, msg))
 		}
-		totalSize += (int64)(len(k)) + (int64)(len(v))
+		for _, msg := range validation.IsValidAnnotationValue(v) {
+			allErrs = append(allErrs, field.Invalid(fldPath, v, msg))
+		}
 	}
-	if totalSize > (int64)(totalAnnotationSizeLimitB) {
+	if len(validation.IsValidAnnotationsTotalSize(annotations)) != 0 {
 		allErrs = append(allErrs, field.TooLong(fldPath, "", totalAnnotationSizeLimitB))
 	}
 	return allErrs
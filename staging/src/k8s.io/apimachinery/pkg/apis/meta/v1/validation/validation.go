@@ -48,7 +48,9 @@ func ValidateLabelSelectorRequirement(sr metav1.LabelSelectorRequirement, fldPat
 	default:
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("operator"), sr.Operator, "not a valid selector operator"))
 	}
-	allErrs = append(allErrs, ValidateLabelName(sr.Key, fldPath.Child("key"))...)
+	for _, msg := range validation.IsValidLabelSelectorKey(sr.Key) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("key"), sr.Key, msg))
+	}
 	return allErrs
 }
 
@@ -166,6 +166,11 @@ type Scope interface {
 
 	// Meta returns any information originally passed to Convert.
 	Meta() *Meta
+
+	// Warn records a non-fatal warning (e.g. a deprecated field that was
+	// dropped) if a WarningCollector was provided in Meta; otherwise it is a
+	// no-op.
+	Warn(message string)
 }
 
 // FieldMappingFunc can convert an input field value into different values, depending on
@@ -215,6 +220,26 @@ type Meta struct {
 	KeyNameMapping FieldMappingFunc
 	// Context is an optional field that callers may use to pass info to conversion functions.
 	Context interface{}
+	// WarningCollector is an optional collector for non-fatal warnings emitted
+	// by conversion functions via Scope.Warn.
+	WarningCollector *WarningCollector
+}
+
+// WarningCollector accumulates non-fatal warnings emitted while a conversion
+// runs, so callers can surface them (e.g. deprecated fields that were
+// ignored) without failing the conversion.
+type WarningCollector struct {
+	warnings []string
+}
+
+// Warn records a warning message.
+func (w *WarningCollector) Warn(message string) {
+	w.warnings = append(w.warnings, message)
+}
+
+// Warnings returns all warnings recorded so far, in order.
+func (w *WarningCollector) Warnings() []string {
+	return w.warnings
 }
 
 // scope contains information about an ongoing conversion.
@@ -274,6 +299,15 @@ func (s *scope) DefaultingInterface(inType reflect.Type) (interface{}, bool) {
 	return value, found
 }
 
+// Warn records a non-fatal warning with the collector carried in Meta, if
+// any.
+func (s *scope) Warn(message string) {
+	if s.meta == nil || s.meta.WarningCollector == nil {
+		return
+	}
+	s.meta.WarningCollector.Warn(message)
+}
+
 // Formats src & dest as indices for printing.
 func (s *scope) setIndices(src, dest int) {
 	s.srcStack.top().key = fmt.Sprintf("[%v]", src)
@@ -217,6 +217,47 @@ func TestConverter_DeepCopy(t *testing.T) {
 	}
 }
 
+func TestConverter_WarningCollector(t *testing.T) {
+	type A struct {
+		Foo        string
+		Deprecated string
+	}
+	type B struct {
+		Foo string
+	}
+	c := NewConverter(DefaultNameFunc)
+	c.Debug = testLogger(t)
+	err := c.RegisterConversionFunc(func(in *A, out *B, s Scope) error {
+		out.Foo = in.Foo
+		if len(in.Deprecated) != 0 {
+			s.Warn("field Deprecated is deprecated and ignored")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	collector := &WarningCollector{}
+	x := A{Foo: "hello", Deprecated: "dropped"}
+	y := B{}
+	if err := c.Convert(&x, &y, 0, &Meta{WarningCollector: collector}); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if e, a := x.Foo, y.Foo; e != a {
+		t.Errorf("expected %v, got %v", e, a)
+	}
+	warnings := collector.Warnings()
+	if len(warnings) != 1 || warnings[0] != "field Deprecated is deprecated and ignored" {
+		t.Errorf("unexpected warnings: %v", warnings)
+	}
+
+	// without a collector the warning is silently dropped
+	if err := c.Convert(&x, &y, 0, nil); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+}
+
 func TestConverter_CallsRegisteredFunctions(t *testing.T) {
 	type A struct {
 		Foo string
@@ -21,7 +21,13 @@ import (
 	"math"
 	"net"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 const qnameCharFmt string = "[A-Za-z0-9]"
@@ -32,12 +38,34 @@ const qualifiedNameMaxLength int = 63
 
 var qualifiedNameRegexp = regexp.MustCompile("^" + qualifiedNameFmt + "$")
 
-// IsQualifiedName tests whether the value passed is what Kubernetes calls a
-// "qualified name".  This is a format used in various places throughout the
-// system.  If the value is not valid, a list of error strings is returned.
-// Otherwise an empty list (or nil) is returned.
-func IsQualifiedName(value string) []string {
-	var errs []string
+// ValidationError is a structured validation failure, identifying which part
+// of the value ("prefix" or "name", or "" for the value as a whole) the
+// message applies to.  It lets callers attach field paths without parsing the
+// flat error strings the other helpers in this package return.
+type ValidationError struct {
+	// Field identifies the part of the value the message applies to, e.g.
+	// "prefix" or "name".  It is empty when the message applies to the value
+	// as a whole.
+	Field string
+	// Value is the offending part of the input.
+	Value string
+	// Message describes why the value is not valid.
+	Message string
+}
+
+// QualifiedNameErrors tests whether the value passed is what Kubernetes calls
+// a "qualified name" and returns structured errors distinguishing prefix
+// failures from name failures.  If the value is valid, an empty list (or nil)
+// is returned.
+func QualifiedNameErrors(value string) []ValidationError {
+	return qualifiedNameErrorsWithMax(value, qualifiedNameMaxLength)
+}
+
+// qualifiedNameErrorsWithMax is QualifiedNameErrors with a caller-supplied
+// limit on the length of the name part.  The prefix, when present, is always
+// validated as a DNS subdomain regardless of maxNameLen.
+func qualifiedNameErrorsWithMax(value string, maxNameLen int) []ValidationError {
+	var errs []ValidationError
 	parts := strings.Split(value, "/")
 	var name string
 	switch len(parts) {
@@ -47,26 +75,114 @@ func IsQualifiedName(value string) []string {
 		var prefix string
 		prefix, name = parts[0], parts[1]
 		if len(prefix) == 0 {
-			errs = append(errs, "prefix part "+EmptyError())
-		} else if msgs := IsDNS1123Subdomain(prefix); len(msgs) != 0 {
-			errs = append(errs, prefixEach(msgs, "prefix part ")...)
+			errs = append(errs, ValidationError{Field: "prefix", Value: prefix, Message: EmptyError()})
+		} else {
+			for _, msg := range IsDNS1123Subdomain(prefix) {
+				errs = append(errs, ValidationError{Field: "prefix", Value: prefix, Message: msg})
+			}
 		}
 	default:
-		return append(errs, "a qualified name "+RegexError(qualifiedNameErrMsg, qualifiedNameFmt, "MyName", "my.name", "123-abc")+
-			" with an optional DNS subdomain prefix and '/' (e.g. 'example.com/MyName')")
+		return append(errs, ValidationError{
+			Value: value,
+			Message: "a qualified name " + RegexError(qualifiedNameErrMsg, qualifiedNameFmt, "MyName", "my.name", "123-abc") +
+				" with an optional DNS subdomain prefix and '/' (e.g. 'example.com/MyName')",
+		})
 	}
 
 	if len(name) == 0 {
-		errs = append(errs, "name part "+EmptyError())
-	} else if len(name) > qualifiedNameMaxLength {
-		errs = append(errs, "name part "+MaxLenError(qualifiedNameMaxLength))
+		errs = append(errs, ValidationError{Field: "name", Value: name, Message: EmptyError()})
+	} else if len(name) > maxNameLen {
+		errs = append(errs, ValidationError{Field: "name", Value: name, Message: MaxLenError(maxNameLen)})
 	}
 	if !qualifiedNameRegexp.MatchString(name) {
-		errs = append(errs, "name part "+RegexError(qualifiedNameErrMsg, qualifiedNameFmt, "MyName", "my.name", "123-abc"))
+		errs = append(errs, ValidationError{Field: "name", Value: name, Message: RegexError(qualifiedNameErrMsg, qualifiedNameFmt, "MyName", "my.name", "123-abc")})
+	}
+	return errs
+}
+
+// IsQualifiedName tests whether the value passed is what Kubernetes calls a
+// "qualified name".  This is a format used in various places throughout the
+// system.  If the value is not valid, a list of error strings is returned.
+// Otherwise an empty list (or nil) is returned.
+func IsQualifiedName(value string) []string {
+	return IsQualifiedNameWithMax(value, qualifiedNameMaxLength)
+}
+
+// IsQualifiedNameWithMax is IsQualifiedName with a caller-supplied limit on
+// the length of the name part, for subsystems whose names allow a different
+// limit than the default 63 characters.  The prefix, when present, is always
+// validated as a DNS subdomain regardless of maxNameLen.
+func IsQualifiedNameWithMax(value string, maxNameLen int) []string {
+	var errs []string
+	for _, err := range qualifiedNameErrorsWithMax(value, maxNameLen) {
+		if err.Field == "" {
+			errs = append(errs, err.Message)
+		} else {
+			errs = append(errs, err.Field+" part "+err.Message)
+		}
+	}
+	return errs
+}
+
+// IsValidLabelSelectorKey tests whether the value passed is a valid key for a
+// label selector matchLabels entry or match expression.  Selector keys are
+// qualified names with an optional DNS subdomain prefix (e.g.
+// 'example.com/MyName').  If the value is not valid, a list of error strings
+// is returned.  Otherwise an empty list (or nil) is returned.
+func IsValidLabelSelectorKey(key string) []string {
+	return IsQualifiedName(key)
+}
+
+// reservedAnnotationPrefixes are key prefixes that are reserved for use by
+// Kubernetes system components.
+var reservedAnnotationPrefixes = []string{"kubernetes.io/", "k8s.io/"}
+
+// IsValidAnnotationKey tests whether the value passed is a valid annotation
+// key.  Annotation keys are qualified names, but keys under the reserved
+// prefixes ("kubernetes.io/", "k8s.io/") are restricted to system use.  If
+// allowReserved is false, keys using a reserved prefix are rejected.  If the
+// value is not valid, a list of error strings is returned.  Otherwise an
+// empty list (or nil) is returned.
+func IsValidAnnotationKey(value string, allowReserved bool) []string {
+	errs := IsQualifiedName(value)
+	if !allowReserved {
+		for _, prefix := range reservedAnnotationPrefixes {
+			if strings.HasPrefix(value, prefix) {
+				errs = append(errs, fmt.Sprintf("the prefix %q is reserved for Kubernetes system use", prefix))
+			}
+		}
 	}
 	return errs
 }
 
+// TotalAnnotationSizeLimitB is the maximum aggregate size in bytes of all
+// annotation keys and values on a single object.
+const TotalAnnotationSizeLimitB int = 256 * (1 << 10) // 256 kB
+
+// IsValidAnnotationValue tests whether the value passed is a valid annotation
+// value.  Unlike label values, annotation values may contain arbitrary text;
+// only the aggregate size limit enforced by IsValidAnnotationsTotalSize
+// applies.  The helper exists so callers have a single point of enforcement
+// should value rules ever tighten.
+func IsValidAnnotationValue(value string) []string {
+	return nil
+}
+
+// IsValidAnnotationsTotalSize tests that the aggregate size of all annotation
+// keys and values does not exceed TotalAnnotationSizeLimitB.  If the limit is
+// exceeded, a list of error strings is returned.  Otherwise an empty list (or
+// nil) is returned.
+func IsValidAnnotationsTotalSize(annotations map[string]string) []string {
+	var totalSize int64
+	for k, v := range annotations {
+		totalSize += (int64)(len(k)) + (int64)(len(v))
+	}
+	if totalSize > (int64)(TotalAnnotationSizeLimitB) {
+		return []string{MaxLenError(TotalAnnotationSizeLimitB)}
+	}
+	return nil
+}
+
 const labelValueFmt string = "(" + qualifiedNameFmt + ")?"
 const labelValueErrMsg string = "a valid label must be an empty string or consist of alphanumeric characters, '-', '_' or '.', and must start and end with an alphanumeric character"
 const LabelValueMaxLength int = 63
@@ -81,12 +197,35 @@ func IsValidLabelValue(value string) []string {
 	if len(value) > LabelValueMaxLength {
 		errs = append(errs, MaxLenError(LabelValueMaxLength))
 	}
+	// A pasted trailing newline or space also fails the regex, but call that
+	// common mistake out specifically instead of the generic message.
+	if strings.TrimSpace(value) != value {
+		errs = append(errs, "must not have leading or trailing whitespace")
+	}
 	if !labelValueRegexp.MatchString(value) {
 		errs = append(errs, RegexError(labelValueErrMsg, labelValueFmt, "MyValue", "my_value", "12345"))
 	}
 	return errs
 }
 
+// ValidateLabels validates a whole set of labels: each key must be a
+// qualified name and each value a valid label value. Messages are prefixed
+// with the offending key, and keys are visited in sorted order so repeated
+// calls on the same map return errors in the same order.
+func ValidateLabels(labels map[string]string) []string {
+	var errs []string
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		errs = append(errs, prefixEach(IsQualifiedName(k), k+": ")...)
+		errs = append(errs, prefixEach(IsValidLabelValue(labels[k]), k+": ")...)
+	}
+	return errs
+}
+
 const dns1123LabelFmt string = "[a-z0-9]([-a-z0-9]*[a-z0-9])?"
 const dns1123LabelErrMsg string = "a DNS-1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character"
 const DNS1123LabelMaxLength int = 63
@@ -125,6 +264,44 @@ func IsDNS1123Subdomain(value string) []string {
 	return errs
 }
 
+const underscoreDNS1123LabelFmt string = "_?" + dns1123LabelFmt
+const underscoreDNS1123SubdomainFmt string = underscoreDNS1123LabelFmt + "(\\." + underscoreDNS1123LabelFmt + ")*"
+const underscoreDNS1123SubdomainErrMsg string = "an SRV-style subdomain must consist of lower case alphanumeric characters, '-' or '.', with labels optionally prefixed by '_', and each label must start (after any '_') and end with an alphanumeric character"
+
+var underscoreDNS1123SubdomainRegexp = regexp.MustCompile("^" + underscoreDNS1123SubdomainFmt + "$")
+
+// IsValidRFC1123SubdomainWithUnderscore tests for a DNS-1123 subdomain whose
+// labels may carry a single leading underscore, as used by SRV and similar
+// discovery records (e.g. "_etcd-server._tcp"). Object names should keep
+// using the strict IsDNS1123Subdomain.
+func IsValidRFC1123SubdomainWithUnderscore(value string) []string {
+	var errs []string
+	if len(value) > DNS1123SubdomainMaxLength {
+		errs = append(errs, MaxLenError(DNS1123SubdomainMaxLength))
+	}
+	if !underscoreDNS1123SubdomainRegexp.MatchString(value) {
+		errs = append(errs, RegexError(underscoreDNS1123SubdomainErrMsg, underscoreDNS1123SubdomainFmt, "_etcd-server._tcp", "example.com"))
+	}
+	return errs
+}
+
+const namespaceNameErrMsg string = "a namespace name must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character"
+
+// IsValidNamespaceName tests that the argument is usable as a namespace name.
+// The syntax is that of a DNS-1123 label, but failures are reported with a
+// namespace-specific message so callers surface errors that tell the user
+// what the name is for.
+func IsValidNamespaceName(value string) []string {
+	var errs []string
+	if len(value) > DNS1123LabelMaxLength {
+		errs = append(errs, MaxLenError(DNS1123LabelMaxLength))
+	}
+	if !dns1123LabelRegexp.MatchString(value) {
+		errs = append(errs, RegexError(namespaceNameErrMsg, dns1123LabelFmt, "my-namespace", "ns-1"))
+	}
+	return errs
+}
+
 const dns1035LabelFmt string = "[a-z]([-a-z0-9]*[a-z0-9])?"
 const dns1035LabelErrMsg string = "a DNS-1035 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character"
 const DNS1035LabelMaxLength int = 63
@@ -144,6 +321,37 @@ func IsDNS1035Label(value string) []string {
 	return errs
 }
 
+// IsValidServiceName tests that the argument is usable as a service name.
+// Service names must be addressable as DNS names, so they are RFC 1035
+// labels: at most 63 characters, starting with a letter rather than a digit.
+// This names the service-specific rule so callers do not have to know which
+// of the DNS label helpers applies.
+func IsValidServiceName(value string) []string {
+	return IsDNS1035Label(value)
+}
+
+const sysctlSegmentFmt string = "[a-z0-9]([-_a-z0-9]*[a-z0-9])?"
+const sysctlNameFmt string = sysctlSegmentFmt + "([./]" + sysctlSegmentFmt + ")*([./]\\*)?"
+const sysctlNameErrMsg string = "a sysctl name must consist of lower case alphanumeric segments separated by '.' or '/', optionally ending in '*' for a wildcard"
+const SysctlNameMaxLength int = 253
+
+var sysctlNameRegexp = regexp.MustCompile("^" + sysctlNameFmt + "$")
+
+// IsValidSysctlName tests that the argument is a valid sysctl name: dot- or
+// slash-separated segments of lower case alphanumerics (with '-' or '_'
+// allowed inside a segment), optionally terminated by a single '*' segment
+// to express a wildcard such as "kernel.*".
+func IsValidSysctlName(value string) []string {
+	var errs []string
+	if len(value) > SysctlNameMaxLength {
+		errs = append(errs, MaxLenError(SysctlNameMaxLength))
+	}
+	if !sysctlNameRegexp.MatchString(value) {
+		errs = append(errs, RegexError(sysctlNameErrMsg, sysctlNameFmt, "kernel.shm_rmid_forced", "net/ipv4/ip_forward", "kernel.*"))
+	}
+	return errs
+}
+
 // wildcard definition - RFC 1034 section 4.3.3.
 // examples:
 // - valid: *.bar.com, *.foo.bar.com
@@ -166,6 +374,67 @@ func IsWildcardDNS1123Subdomain(value string) []string {
 	return errs
 }
 
+// IsValidAPIGroupVersion tests that the argument is a usable apiVersion
+// string: either a bare version (e.g. "v1") or a group/version pair (e.g.
+// "apps/v1beta1") where the group is a DNS subdomain.
+func IsValidAPIGroupVersion(value string) []string {
+	var errs []string
+	parts := strings.Split(value, "/")
+	var version string
+	switch len(parts) {
+	case 1:
+		version = parts[0]
+	case 2:
+		group, v := parts[0], parts[1]
+		version = v
+		if len(group) == 0 {
+			errs = append(errs, "group part "+EmptyError())
+		} else if msgs := IsDNS1123Subdomain(group); len(msgs) != 0 {
+			errs = append(errs, prefixEach(msgs, "group part ")...)
+		}
+	default:
+		return append(errs, "must be a version (e.g. 'v1') or a group/version (e.g. 'apps/v1beta1')")
+	}
+	if len(version) == 0 {
+		errs = append(errs, "version part "+EmptyError())
+	} else if msgs := IsDNS1035Label(version); len(msgs) != 0 {
+		errs = append(errs, prefixEach(msgs, "version part ")...)
+	}
+	return errs
+}
+
+const kindFmt string = "[A-Z][A-Za-z0-9]*"
+const kindErrMsg string = "a kind must be PascalCase: it must start with an uppercase letter and consist only of alphanumeric characters"
+
+var kindRegexp = regexp.MustCompile("^" + kindFmt + "$")
+
+// ValidateOwnerReference tests that the fields of an OwnerReference are
+// usable: apiVersion must be a valid group/version, kind a non-empty
+// PascalCase name, name a DNS subdomain and uid non-empty.  Error messages
+// are prefixed with the field they apply to.
+func ValidateOwnerReference(apiVersion, kind, name, uid string) []string {
+	var errs []string
+	if len(apiVersion) == 0 {
+		errs = append(errs, "apiVersion: "+EmptyError())
+	} else if msgs := IsValidAPIGroupVersion(apiVersion); len(msgs) != 0 {
+		errs = append(errs, prefixEach(msgs, "apiVersion: ")...)
+	}
+	if len(kind) == 0 {
+		errs = append(errs, "kind: "+EmptyError())
+	} else if !kindRegexp.MatchString(kind) {
+		errs = append(errs, "kind: "+RegexError(kindErrMsg, kindFmt, "Pod", "ReplicaSet"))
+	}
+	if len(name) == 0 {
+		errs = append(errs, "name: "+EmptyError())
+	} else if msgs := IsDNS1123Subdomain(name); len(msgs) != 0 {
+		errs = append(errs, prefixEach(msgs, "name: ")...)
+	}
+	if len(uid) == 0 {
+		errs = append(errs, "uid: "+EmptyError())
+	}
+	return errs
+}
+
 const cIdentifierFmt string = "[A-Za-z_][A-Za-z0-9_]*"
 const identifierErrMsg string = "a valid C identifier must start with alphabetic character or '_', followed by a string of alphanumeric characters or '_'"
 
@@ -180,6 +449,21 @@ func IsCIdentifier(value string) []string {
 	return nil
 }
 
+const envVarNameMaxLength int = 128
+
+// IsEnvVarName tests that the argument is a usable environment variable name:
+// a C identifier no longer than 128 characters.  Unlike IsCIdentifier it also
+// enforces the length limit, making it a drop-in validator for container env
+// var names.
+func IsEnvVarName(value string) []string {
+	var errs []string
+	if len(value) > envVarNameMaxLength {
+		errs = append(errs, MaxLenError(envVarNameMaxLength))
+	}
+	errs = append(errs, IsCIdentifier(value)...)
+	return errs
+}
+
 // IsValidPortNum tests that the argument is a valid, non-zero port number.
 func IsValidPortNum(port int) []string {
 	if 1 <= port && port <= 65535 {
@@ -188,6 +472,37 @@ func IsValidPortNum(port int) []string {
 	return []string{InclusiveRangeError(1, 65535)}
 }
 
+// IsValidPortRange tests that the argument is a valid port range in the
+// "low-high" form, where both endpoints are valid port numbers and low does
+// not exceed high.  A single port such as "8080" is accepted as a degenerate
+// range.
+func IsValidPortRange(value string) []string {
+	low := value
+	high := value
+	if idx := strings.Index(value, "-"); idx >= 0 {
+		low, high = value[:idx], value[idx+1:]
+	}
+	lowPort, err := strconv.Atoi(low)
+	if err != nil {
+		return []string{"must be a port number or a range of the form 'low-high' (e.g. 8080 or 8080-9090)"}
+	}
+	highPort, err := strconv.Atoi(high)
+	if err != nil {
+		return []string{"must be a port number or a range of the form 'low-high' (e.g. 8080 or 8080-9090)"}
+	}
+	var errs []string
+	if msgs := IsValidPortNum(lowPort); len(msgs) != 0 {
+		errs = append(errs, prefixEach(msgs, "low: ")...)
+	}
+	if msgs := IsValidPortNum(highPort); len(msgs) != 0 {
+		errs = append(errs, prefixEach(msgs, "high: ")...)
+	}
+	if len(errs) == 0 && lowPort > highPort {
+		errs = append(errs, "range low must be less than or equal to high")
+	}
+	return errs
+}
+
 // Now in libcontainer UID/GID limits is 0 ~ 1<<31 - 1
 // TODO: once we have a type for UID/GID we should make these that type.
 const (
@@ -225,6 +540,9 @@ var portNameOneLetterRegexp = regexp.MustCompile("[a-z]")
 // insensitive.
 func IsValidPortName(port string) []string {
 	var errs []string
+	if len(port) < 1 {
+		errs = append(errs, MinLenError(1))
+	}
 	if len(port) > 15 {
 		errs = append(errs, MaxLenError(15))
 	}
@@ -243,6 +561,98 @@ func IsValidPortName(port string) []string {
 	return errs
 }
 
+// ValidateSelectorRequirement tests that a label selector match expression is
+// usable: the key must be a qualified name, the operator must be one of "In",
+// "NotIn", "Exists" or "DoesNotExist", and the values list must agree with
+// the operator ("In" and "NotIn" require at least one value, "Exists" and
+// "DoesNotExist" require none).  Error messages are prefixed with the field
+// they apply to.
+func ValidateSelectorRequirement(key, operator string, values []string) []string {
+	var errs []string
+	if msgs := IsQualifiedName(key); len(msgs) != 0 {
+		errs = append(errs, prefixEach(msgs, "key: ")...)
+	}
+	switch operator {
+	case "In", "NotIn":
+		if len(values) == 0 {
+			errs = append(errs, fmt.Sprintf("values: must have at least one value when the operator is %q", operator))
+		}
+	case "Exists", "DoesNotExist":
+		if len(values) > 0 {
+			errs = append(errs, fmt.Sprintf("values: must be empty when the operator is %q", operator))
+		}
+	default:
+		errs = append(errs, "operator: must be one of In, NotIn, Exists, DoesNotExist")
+	}
+	return errs
+}
+
+// NormalizePortName returns the canonical (lower case) form of a port name,
+// per RFC 6335 service names are case-insensitive, or the empty string if the
+// name is not a valid port name.  Callers comparing named ports across
+// objects should compare the normalized forms.
+func NormalizePortName(port string) string {
+	normalized := strings.ToLower(port)
+	if msgs := IsValidPortName(normalized); len(msgs) != 0 {
+		return ""
+	}
+	return normalized
+}
+
+// FindDuplicatePortNames returns the port names that appear more than once in
+// the argument.  Names are compared case-insensitively, and duplicates are
+// reported in lower case.
+func FindDuplicatePortNames(names []string) []string {
+	seen := map[string]int{}
+	var duplicates []string
+	for _, name := range names {
+		normalized := strings.ToLower(name)
+		seen[normalized]++
+		if seen[normalized] == 2 {
+			duplicates = append(duplicates, normalized)
+		}
+	}
+	return duplicates
+}
+
+// ValidatePodPortNames tests that the named ports of a pod are valid port
+// names and unique across all of the pod's containers.  Error messages are
+// prefixed with the port name they apply to.
+func ValidatePodPortNames(names []string) []string {
+	var errs []string
+	for _, name := range names {
+		if msgs := IsValidPortName(name); len(msgs) != 0 {
+			errs = append(errs, prefixEach(msgs, name+": ")...)
+		}
+	}
+	for _, duplicate := range FindDuplicatePortNames(names) {
+		errs = append(errs, fmt.Sprintf("port name %q must be unique within the pod", duplicate))
+	}
+	return errs
+}
+
+// AllowSCTPProtocol gates whether IsValidProtocol accepts "SCTP".  SCTP
+// support depends on the network plugin in use, so it is disabled by default.
+var AllowSCTPProtocol = false
+
+// IsValidProtocol tests that the argument is a valid IP protocol for a port:
+// "TCP" or "UDP", plus "SCTP" when AllowSCTPProtocol is set.  Matching is
+// case sensitive; "tcp" is not accepted.
+func IsValidProtocol(value string) []string {
+	switch value {
+	case "TCP", "UDP":
+		return nil
+	case "SCTP":
+		if AllowSCTPProtocol {
+			return nil
+		}
+	}
+	if AllowSCTPProtocol {
+		return []string{`must be "TCP", "UDP" or "SCTP"`}
+	}
+	return []string{`must be "TCP" or "UDP"`}
+}
+
 // IsValidIP tests that the argument is a valid IP address.
 func IsValidIP(value string) []string {
 	if net.ParseIP(value) == nil {
@@ -251,6 +661,115 @@ func IsValidIP(value string) []string {
 	return nil
 }
 
+// IsValidMACAddress tests that the argument is a valid EUI-48 MAC address in
+// the colon-separated or hyphen-separated form.  EUI-64 addresses are only
+// accepted when allowEUI64 is true.  The dotted form some switch vendors use
+// is not accepted.
+func IsValidMACAddress(value string, allowEUI64 bool) bool {
+	if !strings.Contains(value, ":") && !strings.Contains(value, "-") {
+		return false
+	}
+	hw, err := net.ParseMAC(value)
+	if err != nil {
+		return false
+	}
+	if len(hw) == 8 {
+		return allowEUI64
+	}
+	return len(hw) == 6
+}
+
+// IsValidIPv4 tests that the argument is a valid IPv4 address in dotted-quad
+// form.  IPv6 representations of IPv4 addresses such as "::ffff:1.2.3.4" are
+// not accepted.
+func IsValidIPv4(value string) bool {
+	ip := net.ParseIP(value)
+	return ip != nil && ip.To4() != nil && !strings.Contains(value, ":")
+}
+
+// IsValidIPv6 tests that the argument is a valid IPv6 address in colon-
+// separated form, including addresses with an embedded IPv4 suffix such as
+// "::ffff:1.2.3.4".  Plain dotted-quad addresses are not accepted.
+func IsValidIPv6(value string) bool {
+	ip := net.ParseIP(value)
+	return ip != nil && strings.Contains(value, ":")
+}
+
+// IsValidCIDR tests that the argument is a valid CIDR in either address
+// family, such as "10.0.0.0/8" or "2001:db8::/32".
+func IsValidCIDR(value string) []string {
+	if _, _, err := net.ParseCIDR(value); err != nil {
+		return []string{"must be a valid CIDR, (e.g. 10.9.8.0/24 or 2001:db8::/32)"}
+	}
+	return nil
+}
+
+// IsValidCIDRCanonical tests that the argument is a valid CIDR with no host
+// bits set, i.e. the address portion is the network address itself.
+func IsValidCIDRCanonical(value string) []string {
+	ip, ipnet, err := net.ParseCIDR(value)
+	if err != nil {
+		return []string{"must be a valid CIDR, (e.g. 10.9.8.0/24 or 2001:db8::/32)"}
+	}
+	if !ip.Equal(ipnet.IP) {
+		return []string{fmt.Sprintf("must not have host bits set (e.g. %s)", ipnet.String())}
+	}
+	return nil
+}
+
+// IsValidPodCIDR tests that the argument is a valid CIDR whose mask leaves at
+// least minHostBits host bits, so the range is large enough to allocate pod
+// IPs from.
+func IsValidPodCIDR(value string, minHostBits int) []string {
+	_, ipnet, err := net.ParseCIDR(value)
+	if err != nil {
+		return []string{"must be a valid CIDR, (e.g. 10.9.8.0/24)"}
+	}
+	ones, bits := ipnet.Mask.Size()
+	if bits-ones < minHostBits {
+		return []string{fmt.Sprintf("must leave at least %d host bits for pod IPs (e.g. a /%d or larger range)", minHostBits, bits-minHostBits)}
+	}
+	return nil
+}
+
+// IsValidSubPath tests that the argument is a usable volume mount subPath: a
+// non-empty relative path that does not traverse out of the volume.  Variable
+// references in the `$(VAR)` syntax used by subPathExpr are allowed; they are
+// treated as opaque path elements and expanded elsewhere.
+func IsValidSubPath(value string) []string {
+	if len(value) == 0 {
+		return []string{EmptyError()}
+	}
+	if strings.HasPrefix(value, "/") {
+		return []string{"must be a relative path"}
+	}
+	for _, segment := range strings.Split(value, "/") {
+		if segment == ".." {
+			return []string{"must not contain '..'"}
+		}
+	}
+	return nil
+}
+
+// ValidateHostAlias tests that the argument is a usable hosts-file entry: the
+// IP must be valid, and there must be at least one hostname, each a valid DNS
+// subdomain.  Error messages are prefixed with the field they apply to.
+func ValidateHostAlias(ip string, hostnames []string) []string {
+	var errs []string
+	if msgs := IsValidIP(ip); len(msgs) != 0 {
+		errs = append(errs, prefixEach(msgs, "ip: ")...)
+	}
+	if len(hostnames) == 0 {
+		errs = append(errs, "hostnames "+EmptyError())
+	}
+	for i, hostname := range hostnames {
+		if msgs := IsDNS1123Subdomain(hostname); len(msgs) != 0 {
+			errs = append(errs, prefixEach(msgs, fmt.Sprintf("hostnames[%d]: ", i))...)
+		}
+	}
+	return errs
+}
+
 const percentFmt string = "[0-9]+%"
 const percentErrMsg string = "a valid percent string must be a numeric string followed by an ending '%'"
 
@@ -263,6 +782,215 @@ func IsValidPercent(percent string) []string {
 	return nil
 }
 
+// IsValidPercentInRange tests that the argument is a valid percent string
+// whose integer value falls between min and max inclusive.
+func IsValidPercentInRange(percent string, min, max int) []string {
+	if msgs := IsValidPercent(percent); len(msgs) != 0 {
+		return msgs
+	}
+	value, err := strconv.Atoi(strings.TrimSuffix(percent, "%"))
+	if err != nil {
+		return []string{percentErrMsg}
+	}
+	if value < min || value > max {
+		return []string{fmt.Sprintf("must be between %d%% and %d%%", min, max)}
+	}
+	return nil
+}
+
+// IsValidIntOrPercent tests that the argument is either a non-negative
+// integer or a percent string between 0% and 100%, the string forms accepted
+// by intstr.IntOrString fields such as RollingUpdate maxUnavailable.  If the
+// value is neither, a combined message is returned.
+func IsValidIntOrPercent(value string) []string {
+	if strings.HasSuffix(value, "%") {
+		return IsValidPercentInRange(value, 0, 100)
+	}
+	if _, err := strconv.Atoi(value); err == nil && !strings.HasPrefix(value, "-") {
+		return nil
+	}
+	return []string{"must be a non-negative integer or a percent string between 0% and 100%"}
+}
+
+// validateRollingUpdateValue tests a single int-or-percent rolling update
+// field: absolute values must be non-negative and percent strings must fall
+// between 0% and 100% inclusive.
+func validateRollingUpdateValue(value intstr.IntOrString) []string {
+	if value.Type == intstr.String {
+		return IsValidPercentInRange(value.StrVal, 0, 100)
+	}
+	if value.IntValue() < 0 {
+		return []string{"must be greater than or equal to 0"}
+	}
+	return nil
+}
+
+// isZeroIntOrPercent reports whether the value is zero in either form.
+func isZeroIntOrPercent(value intstr.IntOrString) bool {
+	if value.Type == intstr.String {
+		return value.StrVal == "0%"
+	}
+	return value.IntValue() == 0
+}
+
+// ValidateRollingUpdateDeployment tests the maxUnavailable and maxSurge
+// fields of a rolling update deployment strategy.  Each value must be a
+// non-negative integer or a percent string between 0% and 100%, and the two
+// may not both be zero since that would block rollout progress entirely.
+// Error messages are prefixed with the offending field name.
+func ValidateRollingUpdateDeployment(maxUnavailable, maxSurge intstr.IntOrString) []string {
+	var errs []string
+	errs = append(errs, prefixEach(validateRollingUpdateValue(maxUnavailable), "maxUnavailable: ")...)
+	errs = append(errs, prefixEach(validateRollingUpdateValue(maxSurge), "maxSurge: ")...)
+	if len(errs) != 0 {
+		return errs
+	}
+	if isZeroIntOrPercent(maxUnavailable) && isZeroIntOrPercent(maxSurge) {
+		errs = append(errs, "maxUnavailable and maxSurge may not both be zero")
+	}
+	return errs
+}
+
+// ValidateExecCommand tests that the argument is a usable exec probe or
+// lifecycle hook command.  The command must be non-empty and no element may
+// contain control characters (including NUL).  Error messages identify the
+// offending element by index.
+func ValidateExecCommand(cmd []string) []string {
+	if len(cmd) == 0 {
+		return []string{"command " + EmptyError()}
+	}
+	var errs []string
+	for i, arg := range cmd {
+		for _, r := range arg {
+			if r < 0x20 || r == 0x7f {
+				errs = append(errs, fmt.Sprintf("command[%d]: must not contain control characters", i))
+				break
+			}
+		}
+	}
+	return errs
+}
+
+// IsValidQuantity tests that the argument parses as a Kubernetes resource
+// quantity, such as "100m", "1Gi" or "0.5".  If the value is not valid, a
+// list of error strings is returned.  Otherwise an empty list (or nil) is
+// returned.
+func IsValidQuantity(value string) []string {
+	if len(value) == 0 {
+		return []string{EmptyError()}
+	}
+	// ParseQuantity tolerates sign-only strings such as "-" by parsing them
+	// as zero; a quantity must carry at least one digit.
+	if !strings.ContainsAny(value, "0123456789") {
+		return []string{"must be a valid quantity (e.g. '100m', '1Gi', '0.5')"}
+	}
+	if _, err := resource.ParseQuantity(value); err != nil {
+		return []string{"must be a valid quantity (e.g. '100m', '1Gi', '0.5')"}
+	}
+	return nil
+}
+
+// IsValidResourceName tests whether the value passed is a valid resource
+// name, which is a qualified name (e.g. "cpu" or "example.com/gpu").  If the
+// value is not valid, a list of error strings is returned.  Otherwise an
+// empty list (or nil) is returned.
+func IsValidResourceName(value string) []string {
+	return IsQualifiedName(value)
+}
+
+// ValidateResourceList tests that the argument is a usable resource list
+// (requests, limits or capacity): each key must be a valid resource name and
+// each value a valid quantity.  Error messages are prefixed with the resource
+// name they apply to.
+func ValidateResourceList(list map[string]string) []string {
+	var errs []string
+	for name, value := range list {
+		if msgs := IsValidResourceName(name); len(msgs) != 0 {
+			errs = append(errs, prefixEach(msgs, name+": ")...)
+		}
+		if msgs := IsValidQuantity(value); len(msgs) != 0 {
+			errs = append(errs, prefixEach(msgs, name+": ")...)
+		}
+	}
+	return errs
+}
+
+// ValidateMaxLimitRequestRatio tests that each entry of a LimitRange
+// maxLimitRequestRatio map is a valid quantity greater than or equal to 1.
+// Error messages are prefixed with the resource name they apply to.
+func ValidateMaxLimitRequestRatio(ratios map[string]string) []string {
+	var errs []string
+	for name, value := range ratios {
+		if msgs := IsValidQuantity(value); len(msgs) != 0 {
+			errs = append(errs, prefixEach(msgs, name+": ")...)
+			continue
+		}
+		ratio := resource.MustParse(value)
+		if ratio.Cmp(resource.MustParse("1")) < 0 {
+			errs = append(errs, name+": ratio must be greater than or equal to 1")
+		}
+	}
+	return errs
+}
+
+// SupportedExternalTrafficPolicies is the set of valid service external
+// traffic policy values.
+var SupportedExternalTrafficPolicies = []string{"Cluster", "Local"}
+
+// IsValidExternalTrafficPolicy tests that the argument is a supported service
+// external traffic policy.  The empty string is accepted and defaults to
+// "Cluster".
+func IsValidExternalTrafficPolicy(value string) []string {
+	if len(value) == 0 {
+		return nil
+	}
+	for _, policy := range SupportedExternalTrafficPolicies {
+		if value == policy {
+			return nil
+		}
+	}
+	return []string{fmt.Sprintf("must be one of %s", strings.Join(SupportedExternalTrafficPolicies, ", "))}
+}
+
+// ValidateProbeTimings tests that probe timing fields satisfy their documented
+// lower bounds: initialDelaySeconds must be non-negative, while
+// timeoutSeconds, periodSeconds, successThreshold and failureThreshold must
+// all be at least 1.  Error messages are prefixed with the field they apply
+// to.
+func ValidateProbeTimings(initialDelay, timeout, period, success, failure int32) []string {
+	var errs []string
+	if initialDelay < 0 {
+		errs = append(errs, "initialDelaySeconds: must be greater than or equal to 0")
+	}
+	for _, field := range []struct {
+		name  string
+		value int32
+	}{
+		{"timeoutSeconds", timeout},
+		{"periodSeconds", period},
+		{"successThreshold", success},
+		{"failureThreshold", failure},
+	} {
+		if field.value < 1 {
+			errs = append(errs, field.name+": must be greater than or equal to 1")
+		}
+	}
+	return errs
+}
+
+// IsValidMetricsWindow tests that the argument is a usable metrics sampling
+// window: a duration string (e.g. "1m") that is strictly positive.
+func IsValidMetricsWindow(value string) []string {
+	window, err := time.ParseDuration(value)
+	if err != nil {
+		return []string{"must be a valid duration (e.g. '30s', '1m')"}
+	}
+	if window <= 0 {
+		return []string{"must be a positive duration"}
+	}
+	return nil
+}
+
 const httpHeaderNameFmt string = "[-A-Za-z0-9]+"
 const httpHeaderNameErrMsg string = "a valid HTTP header must consist of alphanumeric characters or '-'"
 
@@ -307,6 +1035,12 @@ func MaxLenError(length int) string {
 	return fmt.Sprintf("must be no more than %d characters", length)
 }
 
+// MinLenError returns a string explanation of a "string too short" validation
+// failure.
+func MinLenError(length int) string {
+	return fmt.Sprintf("must be at least %d characters", length)
+}
+
 // RegexError returns a string explanation of a regex validation failure.
 func RegexError(msg string, fmt string, examples ...string) string {
 	if len(examples) == 0 {
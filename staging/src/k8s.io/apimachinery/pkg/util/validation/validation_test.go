@@ -19,6 +19,8 @@ package validation
 import (
 	"strings"
 	"testing"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func TestIsDNS1123Label(t *testing.T) {
@@ -113,6 +115,140 @@ func TestIsDNS1035Label(t *testing.T) {
 	}
 }
 
+func TestIsValidServiceName(t *testing.T) {
+	goodValues := []string{
+		"my-service", "a", "web-1", strings.Repeat("a", 63),
+	}
+	for _, val := range goodValues {
+		if msgs := IsValidServiceName(val); len(msgs) != 0 {
+			t.Errorf("expected true for %q: %v", val, msgs)
+		}
+	}
+
+	badValues := []string{
+		"1-service", "9web", "", "-service", "My-Service", strings.Repeat("a", 64),
+	}
+	for _, val := range badValues {
+		if msgs := IsValidServiceName(val); len(msgs) == 0 {
+			t.Errorf("expected false for %q", val)
+		}
+	}
+}
+
+func TestIsValidRFC1123SubdomainWithUnderscore(t *testing.T) {
+	goodValues := []string{
+		"_tcp.example.com",
+		"_etcd-server._tcp",
+		"_etcd-server-ssl._tcp.example.com",
+		"example.com",
+		"a",
+		"_a",
+	}
+	for _, val := range goodValues {
+		if msgs := IsValidRFC1123SubdomainWithUnderscore(val); len(msgs) != 0 {
+			t.Errorf("expected true for %q: %v", val, msgs)
+		}
+	}
+
+	badValues := []string{
+		"",
+		"bad_.com",
+		"__tcp.example.com",
+		"in_side.example.com",
+		"_-tcp.example.com",
+		"_tcp.example.com.",
+		"Upper._tcp",
+		strings.Repeat("a", 254),
+	}
+	for _, val := range badValues {
+		if msgs := IsValidRFC1123SubdomainWithUnderscore(val); len(msgs) == 0 {
+			t.Errorf("expected false for %q", val)
+		}
+	}
+}
+
+func TestIsValidNamespaceName(t *testing.T) {
+	goodValues := []string{
+		"default", "kube-system", "my-namespace", "ns-1", strings.Repeat("a", 63),
+	}
+	for _, val := range goodValues {
+		if msgs := IsValidNamespaceName(val); len(msgs) != 0 {
+			t.Errorf("expected true for %q: %v", val, msgs)
+		}
+	}
+
+	badValues := []string{
+		"", "-dash-start", "dash-end-", "Upper", "under_score", "dotted.name", strings.Repeat("a", 64),
+	}
+	for _, val := range badValues {
+		msgs := IsValidNamespaceName(val)
+		if len(msgs) == 0 {
+			t.Errorf("expected false for %q", val)
+			continue
+		}
+		// the syntax error must say what the name is for
+		if val != strings.Repeat("a", 64) && !strings.Contains(msgs[0], "namespace") {
+			t.Errorf("expected the message for %q to mention namespace, got %q", val, msgs[0])
+		}
+	}
+}
+
+func TestIsValidSysctlName(t *testing.T) {
+	goodValues := []string{
+		"kernel.shm_rmid_forced",
+		"net.ipv4.ip_local_port_range",
+		"net/ipv4/ip_forward",
+		"kernel.*",
+		"net/ipv4/*",
+		"vm.swappiness",
+		"fs.mqueue.msg_max",
+	}
+	for _, val := range goodValues {
+		if msgs := IsValidSysctlName(val); len(msgs) != 0 {
+			t.Errorf("expected true for %q: %v", val, msgs)
+		}
+	}
+
+	badValues := []string{
+		"",
+		"*",
+		"kernel..shm_rmid_forced",
+		".kernel.shm_rmid_forced",
+		"kernel.shm_rmid_forced.",
+		"kernel.*.forced",
+		"Kernel.shm_rmid_forced",
+		"kernel.shm rmid",
+		"kernel._shm",
+		"kernel.shm_",
+		strings.Repeat("a", 254),
+	}
+	for _, val := range badValues {
+		if msgs := IsValidSysctlName(val); len(msgs) == 0 {
+			t.Errorf("expected false for %q", val)
+		}
+	}
+}
+
+func TestIsEnvVarName(t *testing.T) {
+	goodValues := []string{
+		"PATH", "my_var", "_hidden", "HTTP_PROXY", strings.Repeat("A", 128),
+	}
+	for _, val := range goodValues {
+		if msgs := IsEnvVarName(val); len(msgs) != 0 {
+			t.Errorf("expected true for %q: %v", val, msgs)
+		}
+	}
+
+	badValues := []string{
+		"", "1ABC", "my.var", "my-var", "a b", strings.Repeat("A", 129),
+	}
+	for _, val := range badValues {
+		if msgs := IsEnvVarName(val); len(msgs) == 0 {
+			t.Errorf("expected false for %q", val)
+		}
+	}
+}
+
 func TestIsCIdentifier(t *testing.T) {
 	goodValues := []string{
 		"a", "ab", "abc", "a1", "_a", "a_", "a_b", "a_1", "a__1__2__b", "__abc_123",
@@ -138,6 +274,39 @@ func TestIsCIdentifier(t *testing.T) {
 	}
 }
 
+func TestValidateOwnerReference(t *testing.T) {
+	goodValues := []struct {
+		apiVersion, kind, name, uid string
+	}{
+		{"v1", "Pod", "my-pod", "1234-5678"},
+		{"apps/v1beta1", "Deployment", "my.deployment", "abc"},
+		{"extensions/v1beta1", "ReplicaSet", "rs-1", "def"},
+	}
+	for _, val := range goodValues {
+		if msgs := ValidateOwnerReference(val.apiVersion, val.kind, val.name, val.uid); len(msgs) != 0 {
+			t.Errorf("expected true for %v: %v", val, msgs)
+		}
+	}
+
+	badValues := []struct {
+		apiVersion, kind, name, uid string
+	}{
+		{"", "Pod", "my-pod", "abc"},
+		{"bad version/v1", "Pod", "my-pod", "abc"},
+		{"a/b/c", "Pod", "my-pod", "abc"},
+		{"v1", "", "my-pod", "abc"},
+		{"v1", "pod", "my-pod", "abc"},
+		{"v1", "Pod", "My_Pod", "abc"},
+		{"v1", "Pod", "", "abc"},
+		{"v1", "Pod", "my-pod", ""},
+	}
+	for _, val := range badValues {
+		if msgs := ValidateOwnerReference(val.apiVersion, val.kind, val.name, val.uid); len(msgs) == 0 {
+			t.Errorf("expected false for %v", val)
+		}
+	}
+}
+
 func TestIsValidPortNum(t *testing.T) {
 	goodValues := []int{1, 2, 1000, 16384, 32768, 65535}
 	for _, val := range goodValues {
@@ -186,6 +355,34 @@ func TestIsValidUserId(t *testing.T) {
 	}
 }
 
+func TestIsValidPortRange(t *testing.T) {
+	goodValues := []string{
+		"8080", "1-65535", "8080-9090", "80-80",
+	}
+	for _, val := range goodValues {
+		if msgs := IsValidPortRange(val); len(msgs) != 0 {
+			t.Errorf("expected true for %q: %v", val, msgs)
+		}
+	}
+
+	badValues := []string{
+		"", "0-100", "9090-8080", "abc-def", "8080-", "-9090", "1-65536", "abc",
+	}
+	for _, val := range badValues {
+		if msgs := IsValidPortRange(val); len(msgs) == 0 {
+			t.Errorf("expected false for %q", val)
+		}
+	}
+
+	// non-numeric endpoints and reversed ranges get distinct messages
+	if msgs := IsValidPortRange("abc-def"); len(msgs) != 1 || strings.Contains(msgs[0], "less than") {
+		t.Errorf("unexpected messages for non-numeric range: %v", msgs)
+	}
+	if msgs := IsValidPortRange("9090-8080"); len(msgs) != 1 || !strings.Contains(msgs[0], "less than or equal") {
+		t.Errorf("unexpected messages for reversed range: %v", msgs)
+	}
+}
+
 func TestIsValidPortName(t *testing.T) {
 	goodValues := []string{"telnet", "re-mail-ck", "pop3", "a", "a-1", "1-a", "a-1-b-2-c", "1-a-2-b-3"}
 	for _, val := range goodValues {
@@ -202,6 +399,157 @@ func TestIsValidPortName(t *testing.T) {
 	}
 }
 
+func TestMinLenError(t *testing.T) {
+	if msg := MinLenError(1); msg != "must be at least 1 characters" {
+		t.Errorf("unexpected message: %q", msg)
+	}
+	if msg := MinLenError(3); msg != "must be at least 3 characters" {
+		t.Errorf("unexpected message: %q", msg)
+	}
+
+	// the empty port name reports the minimum-length failure
+	found := false
+	for _, msg := range IsValidPortName("") {
+		if msg == MinLenError(1) {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected the empty port name to fail with %q, got %v", MinLenError(1), IsValidPortName(""))
+	}
+}
+
+func TestIsValidProtocol(t *testing.T) {
+	goodValues := []string{"TCP", "UDP"}
+	for _, val := range goodValues {
+		if msgs := IsValidProtocol(val); len(msgs) != 0 {
+			t.Errorf("expected true for %q: %v", val, msgs)
+		}
+	}
+
+	badValues := []string{"tcp", "udp", "Tcp", "", " TCP", "HTTP", "SCTP"}
+	for _, val := range badValues {
+		if msgs := IsValidProtocol(val); len(msgs) == 0 {
+			t.Errorf("expected false for %q", val)
+		}
+	}
+
+	if msgs := IsValidProtocol("tcp"); len(msgs) != 1 || msgs[0] != `must be "TCP" or "UDP"` {
+		t.Errorf("unexpected message: %v", msgs)
+	}
+
+	AllowSCTPProtocol = true
+	defer func() { AllowSCTPProtocol = false }()
+	if msgs := IsValidProtocol("SCTP"); len(msgs) != 0 {
+		t.Errorf("expected true for SCTP when allowed: %v", msgs)
+	}
+	if msgs := IsValidProtocol("sctp"); len(msgs) != 1 || msgs[0] != `must be "TCP", "UDP" or "SCTP"` {
+		t.Errorf("unexpected message: %v", msgs)
+	}
+}
+
+func TestValidateSelectorRequirement(t *testing.T) {
+	if msgs := ValidateSelectorRequirement("app", "In", []string{"nginx"}); len(msgs) != 0 {
+		t.Errorf("expected success: %v", msgs)
+	}
+	if msgs := ValidateSelectorRequirement("app", "Exists", nil); len(msgs) != 0 {
+		t.Errorf("expected success: %v", msgs)
+	}
+	if msgs := ValidateSelectorRequirement("app", "Exists", []string{"nginx"}); len(msgs) == 0 {
+		t.Errorf("expected failure for Exists with values")
+	} else if !strings.HasPrefix(msgs[0], "values:") {
+		t.Errorf("expected message prefixed with field name, got %q", msgs[0])
+	}
+	if msgs := ValidateSelectorRequirement("app", "In", nil); len(msgs) == 0 {
+		t.Errorf("expected failure for In with no values")
+	}
+	if msgs := ValidateSelectorRequirement("app", "Like", []string{"nginx"}); len(msgs) == 0 {
+		t.Errorf("expected failure for unknown operator")
+	}
+	if msgs := ValidateSelectorRequirement("-bad-", "In", []string{"nginx"}); len(msgs) == 0 {
+		t.Errorf("expected failure for bad key")
+	} else if !strings.HasPrefix(msgs[0], "key:") {
+		t.Errorf("expected message prefixed with field name, got %q", msgs[0])
+	}
+}
+
+func TestNormalizePortName(t *testing.T) {
+	for _, val := range []string{"HTTP", "Http", "http"} {
+		if got := NormalizePortName(val); got != "http" {
+			t.Errorf("expected %q to normalize to \"http\", got %q", val, got)
+		}
+	}
+
+	badValues := []string{"--bad", "", "longerthan15characters", "12345"}
+	for _, val := range badValues {
+		if got := NormalizePortName(val); got != "" {
+			t.Errorf("expected empty string for %q, got %q", val, got)
+		}
+	}
+}
+
+func TestQualifiedNameErrors(t *testing.T) {
+	if errs := QualifiedNameErrors("example.com/MyName"); len(errs) != 0 {
+		t.Errorf("expected success: %v", errs)
+	}
+
+	errs := QualifiedNameErrors("-bad-prefix-/name")
+	if len(errs) != 1 || errs[0].Field != "prefix" || errs[0].Value != "-bad-prefix-" {
+		t.Errorf("expected a single prefix error, got %v", errs)
+	}
+
+	errs = QualifiedNameErrors("example.com/")
+	for _, err := range errs {
+		if err.Field != "name" {
+			t.Errorf("expected only name errors, got %v", err)
+		}
+	}
+	if len(errs) == 0 {
+		t.Errorf("expected name errors for empty name part")
+	}
+
+	errs = QualifiedNameErrors("a/b/c")
+	if len(errs) != 1 || errs[0].Field != "" || errs[0].Value != "a/b/c" {
+		t.Errorf("expected a single whole-value error, got %v", errs)
+	}
+
+	// the flat helper derives its messages from the structured form
+	for _, value := range []string{"ok", "example.com/MyName", "-bad-/name", "a/b/c", ""} {
+		structured := QualifiedNameErrors(value)
+		flat := IsQualifiedName(value)
+		if len(structured) != len(flat) {
+			t.Errorf("mismatched error counts for %q: %v vs %v", value, structured, flat)
+		}
+	}
+}
+
+func TestIsQualifiedNameWithMax(t *testing.T) {
+	name10 := strings.Repeat("a", 10)
+	name11 := strings.Repeat("a", 11)
+
+	if msgs := IsQualifiedNameWithMax(name10, 10); len(msgs) != 0 {
+		t.Errorf("expected true at the boundary: %v", msgs)
+	}
+	if msgs := IsQualifiedNameWithMax(name11, 10); len(msgs) == 0 {
+		t.Errorf("expected false one past the boundary")
+	}
+	if msgs := IsQualifiedNameWithMax("example.com/"+name10, 10); len(msgs) != 0 {
+		t.Errorf("expected prefix to not count against the limit: %v", msgs)
+	}
+	// the prefix is validated as a DNS subdomain regardless of the limit
+	if msgs := IsQualifiedNameWithMax("-bad-/"+name10, 1000); len(msgs) == 0 {
+		t.Errorf("expected false for bad prefix")
+	}
+	// the default helper uses the 63-character limit
+	name63 := strings.Repeat("a", 63)
+	if msgs := IsQualifiedName(name63); len(msgs) != 0 {
+		t.Errorf("expected true for 63 characters: %v", msgs)
+	}
+	if msgs := IsQualifiedName(name63 + "a"); len(msgs) == 0 {
+		t.Errorf("expected false for 64 characters")
+	}
+}
+
 func TestIsQualifiedName(t *testing.T) {
 	successCases := []string{
 		"simple",
@@ -247,6 +595,103 @@ func TestIsQualifiedName(t *testing.T) {
 	}
 }
 
+func TestIsValidAnnotationKey(t *testing.T) {
+	userKeys := []string{
+		"simple",
+		"now-with-dashes",
+		"example.com/MyName",
+		"my.domain.io/annotation",
+	}
+	for i := range userKeys {
+		if errs := IsValidAnnotationKey(userKeys[i], false); len(errs) != 0 {
+			t.Errorf("case[%d]: %q: expected success: %v", i, userKeys[i], errs)
+		}
+	}
+
+	reservedKeys := []string{
+		"kubernetes.io/created-by",
+		"k8s.io/some-key",
+	}
+	for i := range reservedKeys {
+		if errs := IsValidAnnotationKey(reservedKeys[i], true); len(errs) != 0 {
+			t.Errorf("case[%d]: %q: expected success with allowReserved: %v", i, reservedKeys[i], errs)
+		}
+		if errs := IsValidAnnotationKey(reservedKeys[i], false); len(errs) == 0 {
+			t.Errorf("case[%d]: %q: expected failure without allowReserved", i, reservedKeys[i])
+		}
+	}
+
+	errorCases := []string{
+		"nospecialchars%^=@",
+		"only/one/slash",
+		strings.Repeat("a", 254) + "/abc",
+	}
+	for i := range errorCases {
+		if errs := IsValidAnnotationKey(errorCases[i], true); len(errs) == 0 {
+			t.Errorf("case[%d]: %q: expected failure", i, errorCases[i])
+		}
+	}
+}
+
+func TestIsValidAnnotationsTotalSize(t *testing.T) {
+	atTheLimit := map[string]string{
+		"a": strings.Repeat("b", TotalAnnotationSizeLimitB-1),
+	}
+	if errs := IsValidAnnotationsTotalSize(atTheLimit); len(errs) != 0 {
+		t.Errorf("expected success at the size boundary: %v", errs)
+	}
+
+	overTheLimit := map[string]string{
+		"a": strings.Repeat("b", TotalAnnotationSizeLimitB),
+	}
+	if errs := IsValidAnnotationsTotalSize(overTheLimit); len(errs) == 0 {
+		t.Errorf("expected failure one byte past the boundary")
+	}
+
+	// the limit applies to the aggregate of all keys and values
+	split := map[string]string{
+		"a": strings.Repeat("b", TotalAnnotationSizeLimitB/2),
+		"c": strings.Repeat("d", TotalAnnotationSizeLimitB/2),
+	}
+	if errs := IsValidAnnotationsTotalSize(split); len(errs) == 0 {
+		t.Errorf("expected failure when the aggregate exceeds the limit")
+	}
+
+	if errs := IsValidAnnotationValue(strings.Repeat("x", TotalAnnotationSizeLimitB)); len(errs) != 0 {
+		t.Errorf("annotation values have no per-value limit: %v", errs)
+	}
+}
+
+func TestIsValidLabelSelectorKey(t *testing.T) {
+	successCases := []string{
+		"simple",
+		"now-with-dashes",
+		"Uppercase_Is_OK_123",
+		"example.com/MyName",
+		"my.domain.io/key",
+		strings.Repeat("a", 253) + "/" + strings.Repeat("b", 63),
+	}
+	for i := range successCases {
+		if errs := IsValidLabelSelectorKey(successCases[i]); len(errs) != 0 {
+			t.Errorf("case[%d]: %q: expected success: %v", i, successCases[i], errs)
+		}
+	}
+
+	errorCases := []string{
+		"",
+		"nospecialchars%^=@",
+		"only/one/slash",
+		"/no-prefix",
+		strings.Repeat("a", 64),
+		strings.Repeat("a", 254) + "/abc",
+	}
+	for i := range errorCases {
+		if errs := IsValidLabelSelectorKey(errorCases[i]); len(errs) == 0 {
+			t.Errorf("case[%d]: %q: expected failure", i, errorCases[i])
+		}
+	}
+}
+
 func TestIsValidLabelValue(t *testing.T) {
 	successCases := []string{
 		"simple",
@@ -278,6 +723,88 @@ func TestIsValidLabelValue(t *testing.T) {
 			t.Errorf("case[%d] expected failure", i)
 		}
 	}
+
+	// Leading or trailing whitespace gets a specific message, listed before
+	// the generic regex message.
+	whitespaceCases := []string{" value", "value\n", "value ", "\tvalue"}
+	for _, val := range whitespaceCases {
+		errs := IsValidLabelValue(val)
+		if len(errs) == 0 || errs[0] != "must not have leading or trailing whitespace" {
+			t.Errorf("case %q expected a whitespace message first, got %v", val, errs)
+		}
+	}
+	if errs := IsValidLabelValue("internal whitespace"); len(errs) != 1 || strings.Contains(errs[0], "leading or trailing") {
+		t.Errorf("internal whitespace should only fail the regex, got %v", errs)
+	}
+}
+
+func TestValidateLabels(t *testing.T) {
+	goodLabels := map[string]string{
+		"app":                      "frontend",
+		"example.com/role":         "db",
+		"empty-values-are-allowed": "",
+	}
+	if msgs := ValidateLabels(goodLabels); len(msgs) != 0 {
+		t.Errorf("expected success: %v", msgs)
+	}
+	if msgs := ValidateLabels(nil); len(msgs) != 0 {
+		t.Errorf("expected success for nil labels: %v", msgs)
+	}
+
+	badLabels := map[string]string{
+		"-bad-key":  "fine",
+		"good-key":  "bad value!",
+		"other-key": "-also-bad",
+	}
+	msgs := ValidateLabels(badLabels)
+	if len(msgs) != 3 {
+		t.Fatalf("expected three errors, got %v", msgs)
+	}
+	for i, prefix := range []string{"-bad-key: ", "good-key: ", "other-key: "} {
+		if !strings.HasPrefix(msgs[i], prefix) {
+			t.Errorf("expected message %d to be prefixed with %q, got %q", i, prefix, msgs[i])
+		}
+	}
+
+	// errors come back in the same order on every call
+	for i := 0; i < 10; i++ {
+		next := ValidateLabels(badLabels)
+		if len(next) != len(msgs) {
+			t.Fatalf("error count changed between calls: %v vs %v", msgs, next)
+		}
+		for j := range next {
+			if next[j] != msgs[j] {
+				t.Fatalf("error order changed between calls: %v vs %v", msgs, next)
+			}
+		}
+	}
+}
+
+func TestValidatePodPortNames(t *testing.T) {
+	goodValues := [][]string{
+		{"http"},
+		{"http", "https", "metrics"},
+		{},
+	}
+	for _, val := range goodValues {
+		if msgs := ValidatePodPortNames(val); len(msgs) != 0 {
+			t.Errorf("expected true for %v: %v", val, msgs)
+		}
+	}
+
+	badValues := [][]string{
+		// duplicate across containers
+		{"http", "metrics", "http"},
+		// mixed-case collision: the name is invalid and also collides
+		{"http", "HTTP"},
+		// invalid name
+		{"-http"},
+	}
+	for _, val := range badValues {
+		if msgs := ValidatePodPortNames(val); len(msgs) == 0 {
+			t.Errorf("expected false for %v", val)
+		}
+	}
 }
 
 func TestIsValidIP(t *testing.T) {
@@ -313,6 +840,426 @@ func TestIsValidIP(t *testing.T) {
 	}
 }
 
+func TestIsValidMACAddress(t *testing.T) {
+	goodValues := []string{
+		"0a:1b:2c:3d:4e:5f",
+		"0A:1B:2C:3D:4E:5F",
+		"0a-1b-2c-3d-4e-5f",
+	}
+	for _, val := range goodValues {
+		if !IsValidMACAddress(val, false) {
+			t.Errorf("expected true for %q", val)
+		}
+	}
+
+	badValues := []string{
+		"",
+		"0a:1b:2c:3d:4e",
+		"0a:1b:2c:3d:4e:5f:60",
+		"0a:1b:2c:3d:4e:5g",
+		"0a1b.2c3d.4e5f",
+		"not-a-mac",
+	}
+	for _, val := range badValues {
+		if IsValidMACAddress(val, false) {
+			t.Errorf("expected false for %q", val)
+		}
+	}
+
+	// EUI-64 requires the flag
+	eui64 := "0a:1b:2c:3d:4e:5f:60:71"
+	if IsValidMACAddress(eui64, false) {
+		t.Errorf("expected false for %q without allowEUI64", eui64)
+	}
+	if !IsValidMACAddress(eui64, true) {
+		t.Errorf("expected true for %q with allowEUI64", eui64)
+	}
+}
+
+func TestIsValidIPFamilies(t *testing.T) {
+	cases := []struct {
+		value string
+		ipv4  bool
+		ipv6  bool
+	}{
+		{"0.0.0.0", true, false},
+		{"10.9.8.7", true, false},
+		{"255.255.255.255", true, false},
+		{"::", false, true},
+		{"::1", false, true},
+		{"2001:db8::1", false, true},
+		// embedded IPv4 is IPv6, not IPv4
+		{"::ffff:1.2.3.4", false, true},
+		{"", false, false},
+		{"not-an-ip", false, false},
+		{"300.0.0.0", false, false},
+		{"1.2.3", false, false},
+	}
+	for _, tc := range cases {
+		if got := IsValidIPv4(tc.value); got != tc.ipv4 {
+			t.Errorf("IsValidIPv4(%q): expected %v, got %v", tc.value, tc.ipv4, got)
+		}
+		if got := IsValidIPv6(tc.value); got != tc.ipv6 {
+			t.Errorf("IsValidIPv6(%q): expected %v, got %v", tc.value, tc.ipv6, got)
+		}
+	}
+}
+
+func TestIsValidCIDR(t *testing.T) {
+	goodValues := []string{
+		"10.0.0.0/8", "192.168.1.0/24", "0.0.0.0/0", "2001:db8::/32", "::/0",
+		// host bits set is still a valid CIDR
+		"10.9.8.7/24",
+	}
+	for _, val := range goodValues {
+		if msgs := IsValidCIDR(val); len(msgs) != 0 {
+			t.Errorf("expected true for %q: %v", val, msgs)
+		}
+	}
+
+	badValues := []string{
+		"", "10.0.0.0", "10.0.0.0/33", "300.0.0.0/8", "2001:db8::/129", "abc/24",
+	}
+	for _, val := range badValues {
+		if msgs := IsValidCIDR(val); len(msgs) == 0 {
+			t.Errorf("expected false for %q", val)
+		}
+	}
+}
+
+func TestIsValidCIDRCanonical(t *testing.T) {
+	goodValues := []string{
+		"10.0.0.0/8", "192.168.1.0/24", "2001:db8::/32", "::/0",
+	}
+	for _, val := range goodValues {
+		if msgs := IsValidCIDRCanonical(val); len(msgs) != 0 {
+			t.Errorf("expected true for %q: %v", val, msgs)
+		}
+	}
+
+	badValues := []string{
+		"10.9.8.7/24", "2001:db8::1/32", "10.0.0.0", "",
+	}
+	for _, val := range badValues {
+		if msgs := IsValidCIDRCanonical(val); len(msgs) == 0 {
+			t.Errorf("expected false for %q", val)
+		}
+	}
+}
+
+func TestIsValidPodCIDR(t *testing.T) {
+	goodValues := []struct {
+		cidr        string
+		minHostBits int
+	}{
+		{"10.9.8.0/24", 8},
+		{"10.0.0.0/16", 8},
+		{"2001:db8::/64", 16},
+	}
+	for _, val := range goodValues {
+		if msgs := IsValidPodCIDR(val.cidr, val.minHostBits); len(msgs) != 0 {
+			t.Errorf("expected true for %q with %d host bits: %v", val.cidr, val.minHostBits, msgs)
+		}
+	}
+
+	badValues := []struct {
+		cidr        string
+		minHostBits int
+	}{
+		{"10.9.8.0/31", 8},
+		{"10.9.8.0/28", 8},
+		{"2001:db8::/126", 16},
+		{"notacidr", 8},
+		{"10.9.8.7", 8},
+	}
+	for _, val := range badValues {
+		if msgs := IsValidPodCIDR(val.cidr, val.minHostBits); len(msgs) == 0 {
+			t.Errorf("expected false for %q with %d host bits", val.cidr, val.minHostBits)
+		}
+	}
+}
+
+func TestValidateExecCommand(t *testing.T) {
+	goodValues := [][]string{
+		{"sh"},
+		{"sh", "-c", "exit 0"},
+		{"/bin/probe", "--port=8080"},
+	}
+	for _, val := range goodValues {
+		if msgs := ValidateExecCommand(val); len(msgs) != 0 {
+			t.Errorf("expected true for %q: %v", val, msgs)
+		}
+	}
+
+	badValues := [][]string{
+		{},
+		nil,
+		{"sh\x00"},
+		{"sh", "-c", "exit\x000"},
+		{"sh", "a\tb"},
+	}
+	for _, val := range badValues {
+		if msgs := ValidateExecCommand(val); len(msgs) == 0 {
+			t.Errorf("expected false for %q", val)
+		}
+	}
+}
+
+func TestValidateResourceList(t *testing.T) {
+	goodValues := []map[string]string{
+		{"cpu": "100m"},
+		{"cpu": "1", "memory": "1Gi"},
+		{"example.com/gpu": "2"},
+	}
+	for _, val := range goodValues {
+		if msgs := ValidateResourceList(val); len(msgs) != 0 {
+			t.Errorf("expected true for %v: %v", val, msgs)
+		}
+	}
+
+	badValues := []map[string]string{
+		{"bad name!": "1"},
+		{"cpu": "notaquantity"},
+		{"cpu": ""},
+	}
+	for _, val := range badValues {
+		if msgs := ValidateResourceList(val); len(msgs) == 0 {
+			t.Errorf("expected false for %v", val)
+		}
+	}
+
+	// a bad name and a bad quantity in the same list produce one message each
+	list := map[string]string{"bad name!": "1", "memory": "abc"}
+	if msgs := ValidateResourceList(list); len(msgs) != 2 {
+		t.Errorf("expected 2 errors for %v, got %v", list, msgs)
+	}
+}
+
+func TestIsValidQuantity(t *testing.T) {
+	goodValues := []string{
+		"100m", "1Gi", "0.5", "1", "1500Ki", "2e3",
+	}
+	for _, val := range goodValues {
+		if msgs := IsValidQuantity(val); len(msgs) != 0 {
+			t.Errorf("expected true for %q: %v", val, msgs)
+		}
+	}
+
+	badValues := []string{
+		"", "abc", "1GB", "100mi", "-", "1.5.2",
+	}
+	for _, val := range badValues {
+		if msgs := IsValidQuantity(val); len(msgs) == 0 {
+			t.Errorf("expected false for %q", val)
+		}
+	}
+}
+
+func TestValidateMaxLimitRequestRatio(t *testing.T) {
+	goodValues := []map[string]string{
+		{"cpu": "1"},
+		{"cpu": "2", "memory": "10"},
+		{"memory": "1.5"},
+	}
+	for _, val := range goodValues {
+		if msgs := ValidateMaxLimitRequestRatio(val); len(msgs) != 0 {
+			t.Errorf("expected true for %v: %v", val, msgs)
+		}
+	}
+
+	badValues := []map[string]string{
+		{"cpu": "0.5"},
+		{"cpu": "0"},
+		{"memory": "abc"},
+		{"memory": ""},
+	}
+	for _, val := range badValues {
+		if msgs := ValidateMaxLimitRequestRatio(val); len(msgs) == 0 {
+			t.Errorf("expected false for %v", val)
+		}
+	}
+}
+
+func TestIsValidExternalTrafficPolicy(t *testing.T) {
+	goodValues := []string{
+		"Cluster", "Local",
+		"", // defaults to Cluster
+	}
+	for _, val := range goodValues {
+		if msgs := IsValidExternalTrafficPolicy(val); len(msgs) != 0 {
+			t.Errorf("expected true for %q: %v", val, msgs)
+		}
+	}
+
+	badValues := []string{
+		"cluster", "local", "Clutser", "Global",
+	}
+	for _, val := range badValues {
+		if msgs := IsValidExternalTrafficPolicy(val); len(msgs) == 0 {
+			t.Errorf("expected false for %q", val)
+		}
+	}
+}
+
+func TestIsValidSubPath(t *testing.T) {
+	goodValues := []string{
+		"data", "data/logs", "a/b/c", ".hidden", "foo..bar",
+		// subPathExpr variable tokens are opaque path elements
+		"$(POD_NAME)", "logs/$(POD_NAME)/current",
+	}
+	for _, val := range goodValues {
+		if msgs := IsValidSubPath(val); len(msgs) != 0 {
+			t.Errorf("expected true for %q: %v", val, msgs)
+		}
+	}
+
+	badValues := []string{
+		"", "/abs", "/abs/path", "../escape", "data/../../escape", "a/..",
+	}
+	for _, val := range badValues {
+		if msgs := IsValidSubPath(val); len(msgs) == 0 {
+			t.Errorf("expected false for %q", val)
+		}
+	}
+}
+
+func TestValidateHostAlias(t *testing.T) {
+	if msgs := ValidateHostAlias("10.0.0.1", []string{"foo", "foo.bar.example.com"}); len(msgs) != 0 {
+		t.Errorf("expected success: %v", msgs)
+	}
+	if msgs := ValidateHostAlias("not-an-ip", []string{"foo"}); len(msgs) == 0 {
+		t.Errorf("expected failure for bad IP")
+	} else if !strings.HasPrefix(msgs[0], "ip:") {
+		t.Errorf("expected message prefixed with field name, got %q", msgs[0])
+	}
+	if msgs := ValidateHostAlias("10.0.0.1", []string{"Not_Valid"}); len(msgs) == 0 {
+		t.Errorf("expected failure for bad hostname")
+	} else if !strings.HasPrefix(msgs[0], "hostnames[0]:") {
+		t.Errorf("expected message prefixed with field name, got %q", msgs[0])
+	}
+	if msgs := ValidateHostAlias("10.0.0.1", nil); len(msgs) == 0 {
+		t.Errorf("expected failure for empty hostnames")
+	}
+}
+
+func TestIsValidPercentInRange(t *testing.T) {
+	goodValues := []string{
+		"0%", "50%", "100%",
+	}
+	for _, val := range goodValues {
+		if msgs := IsValidPercentInRange(val, 0, 100); len(msgs) != 0 {
+			t.Errorf("expected true for %q: %v", val, msgs)
+		}
+	}
+
+	badValues := []string{
+		"150%", "101%", "-5%", "", "abc%", "50",
+	}
+	for _, val := range badValues {
+		if msgs := IsValidPercentInRange(val, 0, 100); len(msgs) == 0 {
+			t.Errorf("expected false for %q", val)
+		}
+	}
+
+	if msgs := IsValidPercentInRange("150%", 0, 100); len(msgs) != 1 || !strings.Contains(msgs[0], "between 0% and 100%") {
+		t.Errorf("unexpected out-of-range message: %v", msgs)
+	}
+}
+
+func TestIsValidIntOrPercent(t *testing.T) {
+	goodValues := []string{
+		"0", "3", "100", "0%", "25%", "100%",
+	}
+	for _, val := range goodValues {
+		if msgs := IsValidIntOrPercent(val); len(msgs) != 0 {
+			t.Errorf("expected true for %q: %v", val, msgs)
+		}
+	}
+
+	badValues := []string{
+		"150%", "-5", "abc", "", "%",
+	}
+	for _, val := range badValues {
+		if msgs := IsValidIntOrPercent(val); len(msgs) == 0 {
+			t.Errorf("expected false for %q", val)
+		}
+	}
+}
+
+func TestValidateRollingUpdateDeployment(t *testing.T) {
+	goodValues := []struct {
+		maxUnavailable intstr.IntOrString
+		maxSurge       intstr.IntOrString
+	}{
+		{intstr.FromString("25%"), intstr.FromString("25%")},
+		{intstr.FromInt(0), intstr.FromInt(1)},
+		{intstr.FromString("0%"), intstr.FromString("100%")},
+		{intstr.FromInt(2), intstr.FromInt(0)},
+	}
+	for _, val := range goodValues {
+		if msgs := ValidateRollingUpdateDeployment(val.maxUnavailable, val.maxSurge); len(msgs) != 0 {
+			t.Errorf("expected success for %v/%v: %v", val.maxUnavailable, val.maxSurge, msgs)
+		}
+	}
+
+	badValues := []struct {
+		maxUnavailable intstr.IntOrString
+		maxSurge       intstr.IntOrString
+	}{
+		{intstr.FromInt(0), intstr.FromInt(0)},
+		{intstr.FromString("0%"), intstr.FromInt(0)},
+		{intstr.FromString("150%"), intstr.FromString("25%")},
+		{intstr.FromInt(-1), intstr.FromInt(1)},
+		{intstr.FromInt(1), intstr.FromString("abc")},
+	}
+	for _, val := range badValues {
+		if msgs := ValidateRollingUpdateDeployment(val.maxUnavailable, val.maxSurge); len(msgs) == 0 {
+			t.Errorf("expected failure for %v/%v", val.maxUnavailable, val.maxSurge)
+		}
+	}
+
+	if msgs := ValidateRollingUpdateDeployment(intstr.FromString("150%"), intstr.FromString("25%")); len(msgs) != 1 || !strings.HasPrefix(msgs[0], "maxUnavailable:") {
+		t.Errorf("expected message prefixed with field name, got %v", msgs)
+	}
+}
+
+func TestValidateProbeTimings(t *testing.T) {
+	if msgs := ValidateProbeTimings(0, 1, 10, 1, 3); len(msgs) != 0 {
+		t.Errorf("expected success: %v", msgs)
+	}
+	if msgs := ValidateProbeTimings(5, 2, 0, 1, 3); len(msgs) == 0 {
+		t.Errorf("expected failure for zero period")
+	} else if !strings.HasPrefix(msgs[0], "periodSeconds:") {
+		t.Errorf("expected message prefixed with field name, got %q", msgs[0])
+	}
+	if msgs := ValidateProbeTimings(-1, 1, 10, 1, 3); len(msgs) == 0 {
+		t.Errorf("expected failure for negative initial delay")
+	}
+	if msgs := ValidateProbeTimings(0, 0, 0, 0, 0); len(msgs) != 4 {
+		t.Errorf("expected one message per invalid field, got %v", msgs)
+	}
+}
+
+func TestIsValidMetricsWindow(t *testing.T) {
+	goodValues := []string{
+		"1m", "30s", "1h30m", "500ms",
+	}
+	for _, val := range goodValues {
+		if msgs := IsValidMetricsWindow(val); len(msgs) != 0 {
+			t.Errorf("expected true for %q: %v", val, msgs)
+		}
+	}
+
+	badValues := []string{
+		"0s", "-30s", "1", "", "abc",
+	}
+	for _, val := range badValues {
+		if msgs := IsValidMetricsWindow(val); len(msgs) == 0 {
+			t.Errorf("expected false for %q", val)
+		}
+	}
+}
+
 func TestIsHTTPHeaderName(t *testing.T) {
 	goodValues := []string{
 		// Common ones